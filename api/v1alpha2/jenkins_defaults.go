@@ -0,0 +1,200 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	containerProbeURI       = "login"
+	containerProbePortName  = "http"
+	useDeploymentAnnotation = "jenkins.io/use-deployment"
+)
+
+var _ webhook.Defaulter = &Jenkins{}
+
+// DefaultServiceType is the default type of the Jenkins master service, the operator sets it to NodePort
+// when it connects to Jenkins API through the node port
+var DefaultServiceType = corev1.ServiceTypeClusterIP
+
+// +kubebuilder:webhook:path=/mutate-jenkins-io-v1alpha2-jenkins,mutating=true,failurePolicy=fail,sideEffects=None,groups=jenkins.io,resources=jenkins,verbs=create;update,versions=v1alpha2,name=mjenkins.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// SetupDefaultingWebhookWithManager registers the mutating webhook which sets defaults of the Jenkins CR spec
+func (in *Jenkins) SetupDefaultingWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register("/mutate-jenkins-io-v1alpha2-jenkins", admission.DefaultingWebhookFor(in))
+	return nil
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (in *Jenkins) Default() {
+	jenkinslog.Info("default", "name", in.Name)
+	in.SetDefaults()
+}
+
+// SetDefaults sets default values of the Jenkins CR spec which haven't been set by user. The operator calls it
+// on every reconcile loop without writing the spec back, so it must stay idempotent.
+func (in *Jenkins) SetDefaults() {
+	if len(in.Spec.Master.Containers) > 0 && in.Spec.Master.Containers[0].Name != constants.JenkinsMasterContainerName {
+		return // invalid CR, reported by the operator
+	}
+
+	var jenkinsContainer Container
+	if len(in.Spec.Master.Containers) == 0 {
+		jenkinsContainer = Container{Name: constants.JenkinsMasterContainerName}
+	} else {
+		jenkinsContainer = in.Spec.Master.Containers[0]
+	}
+
+	if len(jenkinsContainer.Image) == 0 {
+		jenkinsContainer.Image = constants.DefaultJenkinsMasterImage
+		jenkinsContainer.ImagePullPolicy = corev1.PullAlways
+	}
+	if len(jenkinsContainer.ImagePullPolicy) == 0 {
+		jenkinsContainer.ImagePullPolicy = corev1.PullAlways
+	}
+	if jenkinsContainer.ReadinessProbe == nil {
+		jenkinsContainer.ReadinessProbe = newProbe(60, 1, 10)
+	}
+	if jenkinsContainer.LivenessProbe == nil {
+		jenkinsContainer.LivenessProbe = newProbe(80, 5, 12)
+	}
+	if len(jenkinsContainer.Command) == 0 {
+		jenkinsContainer.Command = []string{
+			"bash",
+			"-c",
+			fmt.Sprintf("%s/%s && exec /sbin/tini -s -- /usr/local/bin/jenkins.sh",
+				constants.JenkinsScriptsVolumePath, constants.InitScriptName),
+		}
+	}
+	if isJavaOpsVariableNotSet(jenkinsContainer) {
+		jenkinsContainer.Env = append(jenkinsContainer.Env, corev1.EnvVar{
+			Name:  constants.JavaOpsVariableName,
+			Value: "-XX:MinRAMPercentage=50.0 -XX:MaxRAMPercentage=80.0 -Djenkins.install.runSetupWizard=false -Djava.awt.headless=true",
+		})
+	}
+	if isResourceRequirementsNotSet(jenkinsContainer.Resources) {
+		jenkinsContainer.Resources = newResourceRequirements("1", "500Mi", "1500m", "3Gi")
+	}
+
+	if len(in.Spec.Master.Containers) <= 1 {
+		in.Spec.Master.Containers = []Container{jenkinsContainer}
+	} else {
+		in.Spec.Master.Containers[0] = jenkinsContainer
+		for i := range in.Spec.Master.Containers[1:] {
+			setContainerDefaults(&in.Spec.Master.Containers[i+1])
+		}
+	}
+
+	if len(in.Spec.Master.BasePlugins) == 0 {
+		for _, plugin := range plugins.BasePlugins() {
+			in.Spec.Master.BasePlugins = append(in.Spec.Master.BasePlugins, Plugin{Name: plugin.Name, Version: plugin.Version})
+		}
+	}
+	if reflect.DeepEqual(in.Spec.Service, Service{}) {
+		in.Spec.Service = Service{
+			Type: DefaultServiceType,
+			Port: constants.DefaultHTTPPortInt32,
+		}
+	}
+	if reflect.DeepEqual(in.Spec.SlaveService, Service{}) {
+		in.Spec.SlaveService = Service{
+			Type: corev1.ServiceTypeClusterIP,
+			Port: constants.DefaultSlavePortInt32,
+		}
+	}
+	if len(in.Spec.Backup.ContainerName) > 0 && in.Spec.Backup.Interval == 0 {
+		in.Spec.Backup.Interval = 30
+	}
+	if in.Spec.JenkinsAPISettings.AuthorizationStrategy == "" {
+		in.Spec.JenkinsAPISettings.AuthorizationStrategy = CreateUserAuthorizationStrategy
+	}
+	if in.Spec.Master.Storage != nil && len(in.Spec.Master.Storage.AccessModes) == 0 {
+		in.Spec.Master.Storage.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+	if in.Spec.RestartPolicy == nil {
+		in.Spec.RestartPolicy = &RestartPolicy{}
+	}
+	if in.Spec.RestartPolicy.Type == "" {
+		in.Spec.RestartPolicy.Type = ImmediateRestartPolicyType
+	}
+	for i := range in.Spec.MaintenanceWindows {
+		if in.Spec.MaintenanceWindows[i].TimeZone == "" {
+			in.Spec.MaintenanceWindows[i].TimeZone = "UTC"
+		}
+	}
+	if in.Spec.Ingress != nil && in.Spec.Ingress.Type == "" {
+		in.Spec.Ingress.Type = IngressIngressType
+	}
+	if in.Spec.Ingress != nil && in.Spec.Ingress.Path == "" {
+		in.Spec.Ingress.Path = "/"
+	}
+	if in.Spec.Master.WorkloadType == "" {
+		in.Spec.Master.WorkloadType = PodWorkloadType
+		// the deprecated annotation used before spec.master.workloadType has been introduced
+		if in.Annotations[useDeploymentAnnotation] == "true" {
+			in.Spec.Master.WorkloadType = DeploymentWorkloadType
+		}
+	}
+}
+
+func setContainerDefaults(container *Container) {
+	if len(container.ImagePullPolicy) == 0 {
+		container.ImagePullPolicy = corev1.PullAlways
+	}
+	if isResourceRequirementsNotSet(container.Resources) {
+		container.Resources = newResourceRequirements("50m", "50Mi", "100m", "100Mi")
+	}
+}
+
+func isJavaOpsVariableNotSet(container Container) bool {
+	for _, env := range container.Env {
+		if env.Name == constants.JavaOpsVariableName {
+			return false
+		}
+	}
+	return true
+}
+
+func isResourceRequirementsNotSet(requirements corev1.ResourceRequirements) bool {
+	return reflect.DeepEqual(requirements, corev1.ResourceRequirements{})
+}
+
+func newProbe(initialDelaySeconds, timeoutSeconds, failureThreshold int32) *corev1.Probe {
+	return &corev1.Probe{
+		Handler: corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   containerProbeURI,
+				Port:   intstr.FromString(containerProbePortName),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+		InitialDelaySeconds: initialDelaySeconds,
+		TimeoutSeconds:      timeoutSeconds,
+		FailureThreshold:    failureThreshold,
+		SuccessThreshold:    int32(1),
+		PeriodSeconds:       int32(1),
+	}
+}
+
+func newResourceRequirements(cpuRequest, memoryRequest, cpuLimit, memoryLimit string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuRequest),
+			corev1.ResourceMemory: resource.MustParse(memoryRequest),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuLimit),
+			corev1.ResourceMemory: resource.MustParse(memoryLimit),
+		},
+	}
+}
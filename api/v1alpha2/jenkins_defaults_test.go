@@ -0,0 +1,109 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJenkins_SetDefaults(t *testing.T) {
+	t.Run("empty spec", func(t *testing.T) {
+		jenkins := &Jenkins{}
+
+		jenkins.SetDefaults()
+
+		if assert.Len(t, jenkins.Spec.Master.Containers, 1) {
+			container := jenkins.Spec.Master.Containers[0]
+			assert.Equal(t, constants.JenkinsMasterContainerName, container.Name)
+			assert.Equal(t, constants.DefaultJenkinsMasterImage, container.Image)
+			assert.Equal(t, corev1.PullAlways, container.ImagePullPolicy)
+			assert.NotNil(t, container.ReadinessProbe)
+			assert.NotNil(t, container.LivenessProbe)
+			assert.NotEmpty(t, container.Command)
+			assert.Equal(t, constants.JavaOpsVariableName, container.Env[0].Name)
+			assert.False(t, isResourceRequirementsNotSet(container.Resources))
+		}
+		assert.NotEmpty(t, jenkins.Spec.Master.BasePlugins)
+		assert.Equal(t, Service{Type: corev1.ServiceTypeClusterIP, Port: constants.DefaultHTTPPortInt32}, jenkins.Spec.Service)
+		assert.Equal(t, Service{Type: corev1.ServiceTypeClusterIP, Port: constants.DefaultSlavePortInt32}, jenkins.Spec.SlaveService)
+		assert.Equal(t, CreateUserAuthorizationStrategy, jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy)
+		assert.Equal(t, PodWorkloadType, jenkins.Spec.Master.WorkloadType)
+		assert.Equal(t, &RestartPolicy{Type: ImmediateRestartPolicyType}, jenkins.Spec.RestartPolicy)
+	})
+	t.Run("maintenance windows time zone", func(t *testing.T) {
+		jenkins := &Jenkins{Spec: JenkinsSpec{MaintenanceWindows: []TimeWindow{
+			{Start: "22:00", End: "02:00"},
+			{Start: "22:00", End: "02:00", TimeZone: "Europe/Warsaw"},
+		}}}
+
+		jenkins.SetDefaults()
+
+		assert.Equal(t, "UTC", jenkins.Spec.MaintenanceWindows[0].TimeZone)
+		assert.Equal(t, "Europe/Warsaw", jenkins.Spec.MaintenanceWindows[1].TimeZone)
+	})
+	t.Run("ingress", func(t *testing.T) {
+		jenkins := &Jenkins{Spec: JenkinsSpec{Ingress: &Ingress{Host: "jenkins.example.com"}}}
+
+		jenkins.SetDefaults()
+
+		assert.Equal(t, IngressIngressType, jenkins.Spec.Ingress.Type)
+		assert.Equal(t, "/", jenkins.Spec.Ingress.Path)
+	})
+	t.Run("deprecated use deployment annotation", func(t *testing.T) {
+		jenkins := &Jenkins{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{useDeploymentAnnotation: "true"}}}
+
+		jenkins.SetDefaults()
+
+		assert.Equal(t, DeploymentWorkloadType, jenkins.Spec.Master.WorkloadType)
+	})
+	t.Run("idempotent", func(t *testing.T) {
+		jenkins := &Jenkins{}
+		jenkins.SetDefaults()
+		defaulted := jenkins.DeepCopy()
+
+		jenkins.SetDefaults()
+
+		assert.Equal(t, defaulted, jenkins)
+	})
+	t.Run("user values are preserved", func(t *testing.T) {
+		jenkins := &Jenkins{
+			Spec: JenkinsSpec{
+				Master: JenkinsMaster{
+					Containers: []Container{
+						{
+							Name:            constants.JenkinsMasterContainerName,
+							Image:           "jenkins/jenkins:lts",
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Env:             []corev1.EnvVar{{Name: constants.JavaOpsVariableName, Value: "-Xmx1g"}},
+						},
+						{Name: "sidecar", Image: "busybox"},
+					},
+				},
+				Backup: Backup{ContainerName: "backup"},
+			},
+		}
+
+		jenkins.SetDefaults()
+
+		master := jenkins.Spec.Master.Containers[0]
+		assert.Equal(t, "jenkins/jenkins:lts", master.Image)
+		assert.Equal(t, corev1.PullIfNotPresent, master.ImagePullPolicy)
+		assert.Equal(t, []corev1.EnvVar{{Name: constants.JavaOpsVariableName, Value: "-Xmx1g"}}, master.Env)
+		sidecar := jenkins.Spec.Master.Containers[1]
+		assert.Equal(t, corev1.PullAlways, sidecar.ImagePullPolicy)
+		assert.False(t, isResourceRequirementsNotSet(sidecar.Resources))
+		assert.Equal(t, uint64(30), jenkins.Spec.Backup.Interval)
+	})
+	t.Run("invalid first container", func(t *testing.T) {
+		jenkins := &Jenkins{Spec: JenkinsSpec{Master: JenkinsMaster{Containers: []Container{{Name: "sidecar"}}}}}
+
+		jenkins.SetDefaults()
+
+		assert.Equal(t, []Container{{Name: "sidecar"}}, jenkins.Spec.Master.Containers)
+		assert.Empty(t, jenkins.Spec.Master.BasePlugins)
+	})
+}
@@ -3,6 +3,8 @@ package v1alpha2
 import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -69,6 +71,170 @@ type JenkinsSpec struct {
 
 	// JenkinsAPISettings defines configuration used by the operator to gain admin access to the Jenkins API
 	JenkinsAPISettings JenkinsAPISettings `json:"jenkinsAPISettings"`
+
+	// RuntimeMetrics enables Jenkins runtime metrics (queue, executors, nodes and jobs) collected by the operator
+	// and exposed by the operator metrics endpoint
+	// +optional
+	RuntimeMetrics *RuntimeMetrics `json:"runtimeMetrics,omitempty"`
+
+	// Monitoring enables Prometheus Operator ServiceMonitor and PrometheusRule for the Jenkins instance,
+	// requires the Jenkins Prometheus metrics plugin and the monitoring.coreos.com API
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// RestartPolicy defines when the operator recreates the Jenkins master pod after changes of spec.master
+	// which require a restart, e.g. labels, annotations, env, volumes or node selector, the MaintenanceWindow
+	// type requires spec.maintenanceWindows
+	// Defaults to Immediate.
+	// +optional
+	RestartPolicy *RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// MaintenanceWindows defines when the operator can execute disruptive actions: the Jenkins master pod recreation
+	// after the operator upgrade, the plugins change or the spec.master change and the restore requested by
+	// spec.restore.recoveryOnce. The actions are executed immediately when the list is empty or the Jenkins CR has
+	// the jenkins.io/maintenance-window-override: "true" annotation.
+	// +optional
+	MaintenanceWindows []TimeWindow `json:"maintenanceWindows,omitempty"`
+
+	// Ingress exposes Jenkins outside the cluster through the networking.k8s.io/v1 Ingress or the Gateway API
+	// HTTPRoute, the Jenkins root URL in JenkinsLocationConfiguration is set to the external URL
+	// +optional
+	Ingress *Ingress `json:"ingress,omitempty"`
+}
+
+// IngressType defines the resource which exposes Jenkins outside the cluster
+type IngressType string
+
+const (
+	// IngressIngressType exposes Jenkins through the networking.k8s.io/v1 Ingress
+	IngressIngressType IngressType = "Ingress"
+	// HTTPRouteIngressType exposes Jenkins through the gateway.networking.k8s.io/v1 HTTPRoute
+	HTTPRouteIngressType IngressType = "HTTPRoute"
+)
+
+// Ingress defines how Jenkins is exposed outside the cluster.
+type Ingress struct {
+	// Type is the resource created by the operator, the HTTPRoute requires the Gateway API
+	// Defaults to Ingress.
+	// +kubebuilder:validation:Enum=Ingress;HTTPRoute
+	// +optional
+	Type IngressType `json:"type,omitempty"`
+
+	// Host is the external host name of Jenkins, e.g. "jenkins.example.com"
+	Host string `json:"host"`
+
+	// Path is the HTTP path prefix of Jenkins, it should match the Jenkins --prefix option
+	// Defaults to /.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// TLSSecretName is the name of the Secret with the TLS certificate of the host used by the Ingress.
+	// The HTTPRoute TLS is terminated by the Gateway listener, the Secret name only tells that the root URL uses https.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// ClassName is the IngressClass name of the Ingress
+	// +optional
+	ClassName string `json:"className,omitempty"`
+
+	// Annotations are added to the Ingress or the HTTPRoute, e.g. to configure the ingress controller
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Gateway is the Gateway which the HTTPRoute is attached to, required by the HTTPRoute type
+	// +optional
+	Gateway *GatewayReference `json:"gateway,omitempty"`
+}
+
+// GatewayReference defines the Gateway API Gateway.
+type GatewayReference struct {
+	// Name is the name of the Gateway
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway
+	// Defaults to the Jenkins CR namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Weekday is a day of the week
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+// TimeWindow defines recurring time range of the maintenance window.
+type TimeWindow struct {
+	// Days are the weekdays when the window starts
+	// Defaults to every day.
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start is the time of the day when the window starts in the HH:MM format, e.g. "22:00"
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the time of the day when the window ends in the HH:MM format, e.g. "02:00", the window ends
+	// on the next day when End isn't after Start
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// TimeZone is the IANA time zone name of Start and End, e.g. "Europe/Warsaw"
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// RestartPolicyType defines when the Jenkins master pod is recreated after the spec change
+type RestartPolicyType string
+
+const (
+	// ImmediateRestartPolicyType recreates the Jenkins master pod right after the spec change, running builds are interrupted
+	ImmediateRestartPolicyType RestartPolicyType = "Immediate"
+	// WaitForIdleRestartPolicyType puts Jenkins in quiet-down mode and recreates the Jenkins master pod
+	// when there are no running builds
+	WaitForIdleRestartPolicyType RestartPolicyType = "WaitForIdle"
+	// MaintenanceWindowRestartPolicyType waits for the maintenance window from spec.maintenanceWindows, then puts
+	// Jenkins in quiet-down mode and recreates the Jenkins master pod when there are no running builds
+	MaintenanceWindowRestartPolicyType RestartPolicyType = "MaintenanceWindow"
+)
+
+// RestartPolicy defines how the Jenkins master pod is recreated after the spec change.
+type RestartPolicy struct {
+	// Type is the restart policy type
+	// Defaults to Immediate.
+	// +kubebuilder:validation:Enum=Immediate;WaitForIdle;MaintenanceWindow
+	// +optional
+	Type RestartPolicyType `json:"type,omitempty"`
+}
+
+// Monitoring defines Prometheus Operator resources created by the operator.
+type Monitoring struct {
+	// Labels are added to the ServiceMonitor and the PrometheusRule, e.g. to match selectors of the Prometheus
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ScrapeInterval is the interval at which Jenkins metrics are scraped
+	// Defaults to 30s.
+	// +optional
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+
+	// MetricsPath is the HTTP path of the Jenkins Prometheus metrics plugin endpoint
+	// Defaults to /prometheus/.
+	// +optional
+	MetricsPath string `json:"metricsPath,omitempty"`
+
+	// DisablePrometheusRule disables the default PrometheusRule with Jenkins down, queue stuck and backup stale alerts
+	// +optional
+	DisablePrometheusRule bool `json:"disablePrometheusRule,omitempty"`
+}
+
+// RuntimeMetrics defines how Jenkins runtime metrics are collected by the operator.
+type RuntimeMetrics struct {
+	// IntervalSeconds defines how often metrics are collected from the Jenkins API
+	// Defaults to 60.
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
 }
 
 // AuthorizationStrategy defines authorization strategy of the operator for the Jenkins API
@@ -116,6 +282,91 @@ type Notification struct {
 	Teams        *MicrosoftTeams   `json:"teams,omitempty"`
 	Mailgun      *Mailgun          `json:"mailgun,omitempty"`
 	SMTP         *SMTP             `json:"smtp,omitempty"`
+	Webhook      *Webhook          `json:"webhook,omitempty"`
+	CloudEvents  *CloudEvents      `json:"cloudEvents,omitempty"`
+	PagerDuty    *PagerDuty        `json:"pagerDuty,omitempty"`
+	Discord      *Discord          `json:"discord,omitempty"`
+	Mattermost   *Mattermost       `json:"mattermost,omitempty"`
+	GoogleChat   *GoogleChat       `json:"googleChat,omitempty"`
+
+	// Template overrides the default title and message of Slack, Microsoft Teams, Discord, Mattermost, Google Chat,
+	// Mailgun and SMTP notifications
+	// +optional
+	Template *NotificationTemplate `json:"template,omitempty"`
+
+	// Filter limits notifications sent by this service to the selected reasons and phases
+	// +optional
+	Filter *NotificationFilter `json:"filter,omitempty"`
+
+	// Delivery defines deduplication, rate limiting and retries of notifications sent by this service
+	// +optional
+	Delivery *NotificationDelivery `json:"delivery,omitempty"`
+}
+
+// NotificationDelivery defines how notifications are delivered to the service.
+type NotificationDelivery struct {
+	// DeduplicationWindowSeconds drops notifications with the same reason and messages
+	// which have already been sent within the window, 0 disables deduplication
+	// +optional
+	DeduplicationWindowSeconds int32 `json:"deduplicationWindowSeconds,omitempty"`
+
+	// RateLimitPerMinute is the maximum average number of notifications sent per minute, 0 disables rate limiting
+	// +optional
+	RateLimitPerMinute int32 `json:"rateLimitPerMinute,omitempty"`
+
+	// RateLimitBurst is the maximum number of notifications sent at once
+	// Defaults to 1.
+	// +optional
+	RateLimitBurst int32 `json:"rateLimitBurst,omitempty"`
+
+	// MaxRetries is the number of retries after a failed delivery, every next retry waits twice as long
+	// +optional
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+
+	// InitialBackoffSeconds is the time to wait before the first retry
+	// Defaults to 1.
+	// +optional
+	InitialBackoffSeconds int32 `json:"initialBackoffSeconds,omitempty"`
+}
+
+// NotificationReason is the type of reason why the notification has been sent.
+// +kubebuilder:validation:Enum=PodRestart;PodCreation;ReconcileLoopFailed;GroovyScriptExecutionFailed;BaseConfigurationFailed;BaseConfigurationComplete;UserConfigurationFailed;UserConfigurationComplete;JenkinsDeletion
+type NotificationReason string
+
+// NotificationPhase is the reconciliation phase in which the notification has been sent.
+// +kubebuilder:validation:Enum=base;user
+type NotificationPhase string
+
+// NotificationFilter defines which notifications are sent, exclusions take precedence over inclusions.
+type NotificationFilter struct {
+	// IncludeReasons is the list of reasons to send, all reasons are sent if empty
+	// +optional
+	IncludeReasons []NotificationReason `json:"includeReasons,omitempty"`
+
+	// ExcludeReasons is the list of reasons not to send
+	// +optional
+	ExcludeReasons []NotificationReason `json:"excludeReasons,omitempty"`
+
+	// IncludePhases is the list of phases to send, all phases are sent if empty
+	// +optional
+	IncludePhases []NotificationPhase `json:"includePhases,omitempty"`
+
+	// ExcludePhases is the list of phases not to send
+	// +optional
+	ExcludePhases []NotificationPhase `json:"excludePhases,omitempty"`
+}
+
+// NotificationTemplate defines Go text/template templates of the notification.
+// Templates have access to .Jenkins (the Jenkins CR), .Phase, .Level, .Reason (the reason type, e.g. PodRestart),
+// .Messages (verbose or short messages depending on the verbose setting), .Short and .Verbose.
+type NotificationTemplate struct {
+	// Title is the template of the notification title
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Body is the template of the notification message
+	// +optional
+	Body string `json:"body,omitempty"`
 }
 
 // Slack is handler for Slack notification channel.
@@ -124,15 +375,56 @@ type Slack struct {
 	WebHookURLSecretKeySelector SecretKeySelector `json:"webHookURLSecretKeySelector"`
 }
 
+// SMTPTLSMode defines how the connection to the SMTP server is secured.
+// +kubebuilder:validation:Enum=starttls;tls;none
+type SMTPTLSMode string
+
+const (
+	// SMTPTLSModeStartTLS - the plain connection must be upgraded with STARTTLS
+	SMTPTLSModeStartTLS SMTPTLSMode = "starttls"
+
+	// SMTPTLSModeTLS - implicit TLS, the connection is encrypted from the start (usually port 465)
+	SMTPTLSModeTLS SMTPTLSMode = "tls"
+
+	// SMTPTLSModeNone - the connection is never encrypted, e.g. for a relay inside the cluster
+	SMTPTLSModeNone SMTPTLSMode = "none"
+)
+
 // SMTP is handler for sending emails via this protocol.
 type SMTP struct {
-	UsernameSecretKeySelector SecretKeySelector `json:"usernameSecretKeySelector"`
-	PasswordSecretKeySelector SecretKeySelector `json:"passwordSecretKeySelector"`
+	// UsernameSecretKeySelector and PasswordSecretKeySelector are the SMTP credentials,
+	// authentication is skipped when both are not set
+	// +optional
+	UsernameSecretKeySelector SecretKeySelector `json:"usernameSecretKeySelector,omitempty"`
+	// +optional
+	PasswordSecretKeySelector SecretKeySelector `json:"passwordSecretKeySelector,omitempty"`
 	Port                      int               `json:"port"`
 	Server                    string            `json:"server"`
-	TLSInsecureSkipVerify     bool              `json:"tlsInsecureSkipVerify,omitempty"`
-	From                      string            `json:"from"`
-	To                        string            `json:"to"`
+
+	// TLSMode is starttls, tls or none
+	// Defaults to tls on port 465, otherwise to STARTTLS if the server supports it.
+	// +optional
+	TLSMode SMTPTLSMode `json:"tlsMode,omitempty"`
+
+	// CABundleSecretKeySelector is the PEM encoded CA bundle used to verify the SMTP server certificate
+	// Defaults to the system CA bundle.
+	// +optional
+	CABundleSecretKeySelector *SecretKeySelector `json:"caBundleSecretKeySelector,omitempty"`
+
+	TLSInsecureSkipVerify bool   `json:"tlsInsecureSkipVerify,omitempty"`
+	From                  string `json:"from"`
+
+	// To is the recipient, use Recipients for more than one, To or Recipients is required
+	// +optional
+	To string `json:"to,omitempty"`
+
+	// Recipients are additional recipients of the email
+	// +optional
+	Recipients []string `json:"recipients,omitempty"`
+
+	// CC are the carbon copy recipients of the email
+	// +optional
+	CC []string `json:"cc,omitempty"`
 }
 
 // MicrosoftTeams is handler for Microsoft MicrosoftTeams notification channel.
@@ -141,6 +433,24 @@ type MicrosoftTeams struct {
 	WebHookURLSecretKeySelector SecretKeySelector `json:"webHookURLSecretKeySelector"`
 }
 
+// Discord is handler for Discord notification channel.
+type Discord struct {
+	// The web hook URL to Discord channel
+	WebHookURLSecretKeySelector SecretKeySelector `json:"webHookURLSecretKeySelector"`
+}
+
+// Mattermost is handler for Mattermost notification channel.
+type Mattermost struct {
+	// The incoming web hook URL to Mattermost channel
+	WebHookURLSecretKeySelector SecretKeySelector `json:"webHookURLSecretKeySelector"`
+}
+
+// GoogleChat is handler for Google Chat notification channel.
+type GoogleChat struct {
+	// The incoming web hook URL to Google Chat space
+	WebHookURLSecretKeySelector SecretKeySelector `json:"webHookURLSecretKeySelector"`
+}
+
 // Mailgun is handler for Mailgun email service notification channel.
 type Mailgun struct {
 	Domain                  string            `json:"domain"`
@@ -149,6 +459,55 @@ type Mailgun struct {
 	From                    string            `json:"from"`
 }
 
+// Webhook is handler for generic HTTP webhook notification channel.
+type Webhook struct {
+	// The URL to which notifications are sent as JSON documents with the POST method
+	URLSecretKeySelector SecretKeySelector `json:"urlSecretKeySelector"`
+
+	// The key used to sign the request body with HMAC-SHA256, the signature is sent in the X-Jenkins-Operator-Signature header
+	// +optional
+	HMACSecretKeySelector *SecretKeySelector `json:"hmacSecretKeySelector,omitempty"`
+
+	// HeadersSecretRef is the secret which key-value pairs are sent as additional HTTP headers
+	// +optional
+	HeadersSecretRef *SecretRef `json:"headersSecretRef,omitempty"`
+}
+
+// CloudEventsMode defines the HTTP protocol binding content mode of CloudEvents.
+// +kubebuilder:validation:Enum=binary;structured
+type CloudEventsMode string
+
+const (
+	// CloudEventsModeBinary - event attributes are sent as HTTP headers and data as HTTP body
+	CloudEventsModeBinary CloudEventsMode = "binary"
+
+	// CloudEventsModeStructured - whole event is sent as JSON in HTTP body
+	CloudEventsModeStructured CloudEventsMode = "structured"
+)
+
+// CloudEvents is handler for CloudEvents 1.0 notification channel.
+type CloudEvents struct {
+	// The sink URL to which CloudEvents are sent
+	URLSecretKeySelector SecretKeySelector `json:"urlSecretKeySelector"`
+
+	// Mode is the HTTP content mode, binary or structured
+	// Defaults to binary.
+	// +optional
+	Mode CloudEventsMode `json:"mode,omitempty"`
+
+	// Source overrides the source attribute of CloudEvents
+	// Defaults to /apis/jenkins.io/v1alpha2/namespaces/<namespace>/jenkins/<name>
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// PagerDuty is handler for PagerDuty Events API v2 notification channel.
+// Warnings trigger incidents which are resolved when the base or user configuration phase completes.
+type PagerDuty struct {
+	// The integration key of the PagerDuty service
+	RoutingKeySecretKeySelector SecretKeySelector `json:"routingKeySecretKeySelector"`
+}
+
 // SecretKeySelector selects a key of a Secret.
 type SecretKeySelector struct {
 	// The name of the secret in the pod's namespace to select from.
@@ -343,6 +702,16 @@ type JenkinsMaster struct {
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 
+	// If specified, the pod's scheduling constraints.
+	// More info: https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#affinity-and-anti-affinity
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Jenkins master pod ought to spread across topology domains.
+	// More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-topology-spread-constraints/
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
 	// BasePlugins contains plugins required by operator
 	// +optional
 	// Defaults to :
@@ -376,8 +745,66 @@ type JenkinsMaster struct {
 	// HostAliases for Jenkins master pod and SeedJob agent
 	// +optional
 	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// WorkloadType is the kind of the Kubernetes resource which runs the Jenkins master pod. The operator creates
+	// the Pod itself and recreates it on spec changes when set to Pod, when set to Deployment or StatefulSet
+	// the pod template is updated and the Jenkins master pod is replaced, the old pod is removed before the new
+	// one is started.
+	// +optional
+	// Defaults to: Pod
+	WorkloadType WorkloadType `json:"workloadType,omitempty"`
+
+	// Storage is the persistent storage of the Jenkins home directory provisioned through the StatefulSet
+	// volumeClaimTemplates, it requires workloadType StatefulSet. The persistent volume claim is expanded when
+	// the size grows and it isn't deleted together with the Jenkins CR.
+	// +optional
+	Storage *Storage `json:"storage,omitempty"`
+
+	// PodDisruptionBudget defines the PodDisruptionBudget of the Jenkins master pod managed by the operator
+	// +optional
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+}
+
+// PodDisruptionBudget defines the PodDisruptionBudget which protects the Jenkins master pod from the eviction.
+type PodDisruptionBudget struct {
+	// Enabled tells if the operator creates the PodDisruptionBudget which blocks the eviction of the Jenkins master
+	// pod, e.g. by a node drain. When the node of the pod is cordoned, the operator puts Jenkins in quiet-down mode and
+	// recreates the pod on another node according to spec.restartPolicy and spec.maintenanceWindows.
+	// Nodes are cluster scoped, the operator limited to namespaces needs a ClusterRole with the get nodes
+	// permission to detect the cordoned node, the namespaced Role can't grant it.
+	Enabled bool `json:"enabled"`
+}
+
+// Storage defines the persistent volume claim of the Jenkins home directory
+type Storage struct {
+	// StorageClassName is the name of the StorageClass of the persistent volume claim, the default StorageClass
+	// is used when it's empty. It can't be changed after the persistent volume claim has been created.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Size of the persistent volume claim, it can only grow and the StorageClass must allow volume expansion.
+	Size resource.Quantity `json:"size"`
+
+	// AccessModes of the persistent volume claim. They can't be changed after the persistent volume claim has
+	// been created.
+	// +optional
+	// Defaults to: [ReadWriteOnce]
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
 }
 
+// WorkloadType defines the kind of the Kubernetes resource which runs the Jenkins master pod
+// +kubebuilder:validation:Enum=Pod;Deployment;StatefulSet
+type WorkloadType string
+
+const (
+	// PodWorkloadType the operator manages the Jenkins master pod directly
+	PodWorkloadType WorkloadType = "Pod"
+	// DeploymentWorkloadType the Jenkins master pod is managed by a Deployment
+	DeploymentWorkloadType WorkloadType = "Deployment"
+	// StatefulSetWorkloadType the Jenkins master pod is managed by a StatefulSet
+	StatefulSetWorkloadType WorkloadType = "StatefulSet"
+)
+
 // Service defines Kubernetes service attributes
 type Service struct {
 	// Annotations is an unstructured key value map stored with a resource that may be
@@ -487,10 +914,115 @@ type JenkinsStatus struct {
 	// AppliedGroovyScripts is a list with all applied groovy scripts in Jenkins by the operator
 	// +optional
 	AppliedGroovyScripts []AppliedGroovyScript `json:"appliedGroovyScripts,omitempty"`
+
+	// Conditions represent the latest available observations of the Jenkins state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Validation contains messages of the failed Jenkins CR validation, it's cleared when the CR becomes valid
+	// +optional
+	Validation *ValidationStatus `json:"validation,omitempty"`
+
+	// PendingRestart is the Jenkins master pod restart deferred by spec.restartPolicy, it's cleared when the pod
+	// has been recreated or the changes have been reverted
+	// +optional
+	PendingRestart *PendingRestart `json:"pendingRestart,omitempty"`
+
+	// NextMaintenanceWindow is the current or the next window from spec.maintenanceWindows when disruptive
+	// actions are allowed
+	// +optional
+	NextMaintenanceWindow *MaintenanceWindowStatus `json:"nextMaintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindowStatus defines the time range of the maintenance window
+type MaintenanceWindowStatus struct {
+	// Start is a time when the maintenance window starts
+	Start metav1.Time `json:"start"`
+
+	// End is a time when the maintenance window ends
+	End metav1.Time `json:"end"`
+}
+
+// PendingRestart defines the Jenkins master pod restart which waits for Jenkins being idle or the maintenance window
+type PendingRestart struct {
+	// Reasons is a list of changes which require the Jenkins master pod restart
+	Reasons []string `json:"reasons"`
+
+	// Since is a time when the restart has been deferred
+	Since metav1.Time `json:"since"`
+
+	// QuietDown tells if the operator has put Jenkins in quiet-down mode, new builds aren't started
+	// +optional
+	QuietDown bool `json:"quietDown,omitempty"`
+}
+
+// ValidationStatus defines the result of the failed Jenkins CR validation
+type ValidationStatus struct {
+	// ObservedGeneration is the Jenkins CR generation the messages apply to
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// Messages is a list of the validation errors which have to be fixed in the Jenkins CR
+	Messages []string `json:"messages"`
+}
+
+const (
+	// ConditionReady tells if Jenkins is fully configured and ready to use
+	ConditionReady = "Ready"
+	// ConditionBaseConfigured tells if the base configuration phase has been completed
+	ConditionBaseConfigured = "BaseConfigured"
+	// ConditionUserConfigured tells if the user configuration phase has been completed
+	ConditionUserConfigured = "UserConfigured"
+	// ConditionValidationFailed tells if the Jenkins CR didn't pass the validation
+	ConditionValidationFailed = "ValidationFailed"
+	// ConditionBackupHealthy tells if the last backup has been successful
+	ConditionBackupHealthy = "BackupHealthy"
+	// ConditionDegraded tells if the last reconcile loop has failed
+	ConditionDegraded = "Degraded"
+	// ConditionStalled tells if the reconcile loop keeps failing with the same error and is retried with backoff,
+	// it's reset by the jenkins.io/reset-reconcile-errors annotation
+	ConditionStalled = "Stalled"
+	// ConditionRestartPending tells if the Jenkins master pod restart has been deferred by spec.restartPolicy
+	ConditionRestartPending = "RestartPending"
+)
+
+// maxConditionMessageLength is the maximal length of the condition message accepted by the API server
+const maxConditionMessageLength = 32768
+
+// SetCondition sets the status condition observed for the current generation, it's persisted with the next status update
+func (in *Jenkins) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	if len(message) > maxConditionMessageLength {
+		message = message[:maxConditionMessageLength]
+	}
+	meta.SetStatusCondition(&in.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: in.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// RemoveCondition removes the status condition, it's persisted with the next status update
+func (in *Jenkins) RemoveCondition(conditionType string) {
+	// meta.RemoveStatusCondition panics on an empty list
+	if meta.FindStatusCondition(in.Status.Conditions, conditionType) == nil {
+		return
+	}
+	meta.RemoveStatusCondition(&in.Status.Conditions, conditionType)
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Base",type=string,JSONPath=`.status.conditions[?(@.type=="BaseConfigured")].status`
+// +kubebuilder:printcolumn:name="User",type=string,JSONPath=`.status.conditions[?(@.type=="UserConfigured")].status`
+// +kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -618,6 +1150,10 @@ type Backup struct {
 
 	// MakeBackupBeforePodDeletion tells operator to make backup before Jenkins master pod deletion
 	MakeBackupBeforePodDeletion bool `json:"makeBackupBeforePodDeletion"`
+
+	// MakeBackupBeforeDeletion tells operator to make the last backup before Jenkins CR deletion
+	// +optional
+	MakeBackupBeforeDeletion bool `json:"makeBackupBeforeDeletion,omitempty"`
 }
 
 // Restore defines configuration of Jenkins backup restore operation.
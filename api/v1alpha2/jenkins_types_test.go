@@ -0,0 +1,48 @@
+package v1alpha2
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJenkins_SetCondition(t *testing.T) {
+	t.Run("new condition", func(t *testing.T) {
+		jenkins := &Jenkins{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+
+		jenkins.SetCondition(ConditionReady, metav1.ConditionFalse, "InProgress", "in progress")
+
+		condition := meta.FindStatusCondition(jenkins.Status.Conditions, ConditionReady)
+		if assert.NotNil(t, condition) {
+			assert.Equal(t, metav1.ConditionFalse, condition.Status)
+			assert.Equal(t, int64(2), condition.ObservedGeneration)
+			assert.Equal(t, "InProgress", condition.Reason)
+			assert.False(t, condition.LastTransitionTime.IsZero())
+		}
+	})
+	t.Run("same status keeps transition time", func(t *testing.T) {
+		jenkins := &Jenkins{ObjectMeta: metav1.ObjectMeta{Generation: 1}}
+		jenkins.SetCondition(ConditionDegraded, metav1.ConditionTrue, "ReconcileFailed", "first")
+		transitionTime := metav1.NewTime(jenkins.Status.Conditions[0].LastTransitionTime.Add(-time.Minute))
+		jenkins.Status.Conditions[0].LastTransitionTime = transitionTime
+		jenkins.Generation = 2
+
+		jenkins.SetCondition(ConditionDegraded, metav1.ConditionTrue, "ReconcileFailed", "second")
+
+		assert.Len(t, jenkins.Status.Conditions, 1)
+		assert.Equal(t, transitionTime, jenkins.Status.Conditions[0].LastTransitionTime)
+		assert.Equal(t, "second", jenkins.Status.Conditions[0].Message)
+		assert.Equal(t, int64(2), jenkins.Status.Conditions[0].ObservedGeneration)
+	})
+	t.Run("too long message", func(t *testing.T) {
+		jenkins := &Jenkins{}
+
+		jenkins.SetCondition(ConditionDegraded, metav1.ConditionTrue, "ReconcileFailed", strings.Repeat("a", maxConditionMessageLength+1))
+
+		assert.Len(t, jenkins.Status.Conditions[0].Message, maxConditionMessageLength)
+	})
+}
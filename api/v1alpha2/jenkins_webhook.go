@@ -34,6 +34,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var (
@@ -51,10 +52,10 @@ const (
 	defaultCheckingPeriod   = 12 * time.Minute
 )
 
+// SetupWebhookWithManager registers the validating webhook of the plugins security warnings
 func (in *Jenkins) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(in).
-		Complete()
+	mgr.GetWebhookServer().Register("/validate-jenkins-io-v1alpha2-jenkins", admission.ValidatingWebhookFor(in))
+	return nil
 }
 
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
@@ -0,0 +1,44 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsNotificationPolicySpec defines the desired state of JenkinsNotificationPolicy
+type JenkinsNotificationPolicySpec struct {
+	// Selector selects Jenkins CRs in the policy namespace which the notifications are sent for,
+	// an empty selector selects all Jenkins CRs in the namespace
+	// +optional
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Notifications defines services used to send notifications about selected Jenkins CRs,
+	// they are sent in addition to the notifications defined in the Jenkins CR
+	Notifications []Notification `json:"notifications"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=jnp
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsNotificationPolicy is the Schema for the jenkinsnotificationpolicies API
+type JenkinsNotificationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the notifications and the Jenkins CRs they are sent for
+	Spec JenkinsNotificationPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsNotificationPolicyList contains a list of JenkinsNotificationPolicy
+type JenkinsNotificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JenkinsNotificationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JenkinsNotificationPolicy{}, &JenkinsNotificationPolicyList{})
+}
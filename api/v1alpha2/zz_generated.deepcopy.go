@@ -23,6 +23,7 @@ package v1alpha2
 import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -57,6 +58,22 @@ func (in *Backup) DeepCopy() *Backup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEvents) DeepCopyInto(out *CloudEvents) {
+	*out = *in
+	out.URLSecretKeySelector = in.URLSecretKeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEvents.
+func (in *CloudEvents) DeepCopy() *CloudEvents {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
 	*out = *in
@@ -181,6 +198,53 @@ func (in *Customization) DeepCopy() *Customization {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Discord) DeepCopyInto(out *Discord) {
+	*out = *in
+	out.WebHookURLSecretKeySelector = in.WebHookURLSecretKeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Discord.
+func (in *Discord) DeepCopy() *Discord {
+	if in == nil {
+		return nil
+	}
+	out := new(Discord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayReference) DeepCopyInto(out *GatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayReference.
+func (in *GatewayReference) DeepCopy() *GatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoogleChat) DeepCopyInto(out *GoogleChat) {
+	*out = *in
+	out.WebHookURLSecretKeySelector = in.WebHookURLSecretKeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleChat.
+func (in *GoogleChat) DeepCopy() *GoogleChat {
+	if in == nil {
+		return nil
+	}
+	out := new(GoogleChat)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GroovyScripts) DeepCopyInto(out *GroovyScripts) {
 	*out = *in
@@ -217,6 +281,33 @@ func (in *Handler) DeepCopy() *Handler {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ingress) DeepCopyInto(out *Ingress) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ingress.
+func (in *Ingress) DeepCopy() *Ingress {
+	if in == nil {
+		return nil
+	}
+	out := new(Ingress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Jenkins) DeepCopyInto(out *Jenkins) {
 	*out = *in
@@ -346,6 +437,18 @@ func (in *JenkinsMaster) DeepCopyInto(out *JenkinsMaster) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.BasePlugins != nil {
 		in, out := &in.BasePlugins, &out.BasePlugins
 		*out = make([]Plugin, len(*in))
@@ -363,6 +466,16 @@ func (in *JenkinsMaster) DeepCopyInto(out *JenkinsMaster) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(Storage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudget)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsMaster.
@@ -375,6 +488,87 @@ func (in *JenkinsMaster) DeepCopy() *JenkinsMaster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNotificationPolicy) DeepCopyInto(out *JenkinsNotificationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNotificationPolicy.
+func (in *JenkinsNotificationPolicy) DeepCopy() *JenkinsNotificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNotificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsNotificationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNotificationPolicyList) DeepCopyInto(out *JenkinsNotificationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JenkinsNotificationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNotificationPolicyList.
+func (in *JenkinsNotificationPolicyList) DeepCopy() *JenkinsNotificationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNotificationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsNotificationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsNotificationPolicySpec) DeepCopyInto(out *JenkinsNotificationPolicySpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]Notification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsNotificationPolicySpec.
+func (in *JenkinsNotificationPolicySpec) DeepCopy() *JenkinsNotificationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsNotificationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
 	*out = *in
@@ -404,6 +598,33 @@ func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
 	}
 	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
 	out.JenkinsAPISettings = in.JenkinsAPISettings
+	if in.RuntimeMetrics != nil {
+		in, out := &in.RuntimeMetrics, &out.RuntimeMetrics
+		*out = new(RuntimeMetrics)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(RestartPolicy)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]TimeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(Ingress)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsSpec.
@@ -441,6 +662,28 @@ func (in *JenkinsStatus) DeepCopyInto(out *JenkinsStatus) {
 		*out = make([]AppliedGroovyScript, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(ValidationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingRestart != nil {
+		in, out := &in.PendingRestart, &out.PendingRestart
+		*out = new(PendingRestart)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NextMaintenanceWindow != nil {
+		in, out := &in.NextMaintenanceWindow, &out.NextMaintenanceWindow
+		*out = new(MaintenanceWindowStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsStatus.
@@ -469,6 +712,39 @@ func (in *Mailgun) DeepCopy() *Mailgun {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowStatus) DeepCopyInto(out *MaintenanceWindowStatus) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowStatus.
+func (in *MaintenanceWindowStatus) DeepCopy() *MaintenanceWindowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mattermost) DeepCopyInto(out *Mattermost) {
+	*out = *in
+	out.WebHookURLSecretKeySelector = in.WebHookURLSecretKeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mattermost.
+func (in *Mattermost) DeepCopy() *Mattermost {
+	if in == nil {
+		return nil
+	}
+	out := new(Mattermost)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MicrosoftTeams) DeepCopyInto(out *MicrosoftTeams) {
 	*out = *in
@@ -485,6 +761,28 @@ func (in *MicrosoftTeams) DeepCopy() *MicrosoftTeams {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Notification) DeepCopyInto(out *Notification) {
 	*out = *in
@@ -506,6 +804,51 @@ func (in *Notification) DeepCopyInto(out *Notification) {
 	if in.SMTP != nil {
 		in, out := &in.SMTP, &out.SMTP
 		*out = new(SMTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(Webhook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = new(CloudEvents)
+		**out = **in
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDuty)
+		**out = **in
+	}
+	if in.Discord != nil {
+		in, out := &in.Discord, &out.Discord
+		*out = new(Discord)
+		**out = **in
+	}
+	if in.Mattermost != nil {
+		in, out := &in.Mattermost, &out.Mattermost
+		*out = new(Mattermost)
+		**out = **in
+	}
+	if in.GoogleChat != nil {
+		in, out := &in.GoogleChat, &out.GoogleChat
+		*out = new(GoogleChat)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(NotificationTemplate)
+		**out = **in
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(NotificationFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(NotificationDelivery)
 		**out = **in
 	}
 }
@@ -520,6 +863,108 @@ func (in *Notification) DeepCopy() *Notification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationDelivery) DeepCopyInto(out *NotificationDelivery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDelivery.
+func (in *NotificationDelivery) DeepCopy() *NotificationDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationFilter) DeepCopyInto(out *NotificationFilter) {
+	*out = *in
+	if in.IncludeReasons != nil {
+		in, out := &in.IncludeReasons, &out.IncludeReasons
+		*out = make([]NotificationReason, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeReasons != nil {
+		in, out := &in.ExcludeReasons, &out.ExcludeReasons
+		*out = make([]NotificationReason, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludePhases != nil {
+		in, out := &in.IncludePhases, &out.IncludePhases
+		*out = make([]NotificationPhase, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePhases != nil {
+		in, out := &in.ExcludePhases, &out.ExcludePhases
+		*out = make([]NotificationPhase, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationFilter.
+func (in *NotificationFilter) DeepCopy() *NotificationFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationTemplate) DeepCopyInto(out *NotificationTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTemplate.
+func (in *NotificationTemplate) DeepCopy() *NotificationTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDuty) DeepCopyInto(out *PagerDuty) {
+	*out = *in
+	out.RoutingKeySecretKeySelector = in.RoutingKeySecretKeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDuty.
+func (in *PagerDuty) DeepCopy() *PagerDuty {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDuty)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingRestart) DeepCopyInto(out *PendingRestart) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingRestart.
+func (in *PendingRestart) DeepCopy() *PendingRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Plugin) DeepCopyInto(out *Plugin) {
 	*out = *in
@@ -594,6 +1039,36 @@ func (in *PluginsInfo) DeepCopy() *PluginsInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudget) DeepCopyInto(out *PodDisruptionBudget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudget.
+func (in *PodDisruptionBudget) DeepCopy() *PodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartPolicy) DeepCopyInto(out *RestartPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartPolicy.
+func (in *RestartPolicy) DeepCopy() *RestartPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Restore) DeepCopyInto(out *Restore) {
 	*out = *in
@@ -611,11 +1086,41 @@ func (in *Restore) DeepCopy() *Restore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeMetrics) DeepCopyInto(out *RuntimeMetrics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeMetrics.
+func (in *RuntimeMetrics) DeepCopy() *RuntimeMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SMTP) DeepCopyInto(out *SMTP) {
 	*out = *in
 	out.UsernameSecretKeySelector = in.UsernameSecretKeySelector
 	out.PasswordSecretKeySelector = in.PasswordSecretKeySelector
+	if in.CABundleSecretKeySelector != nil {
+		in, out := &in.CABundleSecretKeySelector, &out.CABundleSecretKeySelector
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CC != nil {
+		in, out := &in.CC, &out.CC
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMTP.
@@ -762,6 +1267,72 @@ func (in *Slack) DeepCopy() *Slack {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Storage) DeepCopyInto(out *Storage) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	out.Size = in.Size.DeepCopy()
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Storage.
+func (in *Storage) DeepCopy() *Storage {
+	if in == nil {
+		return nil
+	}
+	out := new(Storage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeWindow) DeepCopyInto(out *TimeWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeWindow.
+func (in *TimeWindow) DeepCopy() *TimeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationStatus) DeepCopyInto(out *ValidationStatus) {
+	*out = *in
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationStatus.
+func (in *ValidationStatus) DeepCopy() *ValidationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Version) DeepCopyInto(out *Version) {
 	*out = *in
@@ -796,3 +1367,29 @@ func (in *Warning) DeepCopy() *Warning {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Webhook) DeepCopyInto(out *Webhook) {
+	*out = *in
+	out.URLSecretKeySelector = in.URLSecretKeySelector
+	if in.HMACSecretKeySelector != nil {
+		in, out := &in.HMACSecretKeySelector, &out.HMACSecretKeySelector
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.HeadersSecretRef != nil {
+		in, out := &in.HeadersSecretRef, &out.HeadersSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Webhook.
+func (in *Webhook) DeepCopy() *Webhook {
+	if in == nil {
+		return nil
+	}
+	out := new(Webhook)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -43,3 +43,12 @@ app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
 {{- end }}
 app.kubernetes.io/managed-by: {{ .Release.Service }}
 {{- end -}}
+
+{{/*
+Tells if any admission webhook is enabled, the webhooks share the certificate and the service
+*/}}
+{{- define "jenkins-operator.webhooksEnabled" -}}
+{{- if or .Values.webhook.enabled .Values.webhook.defaulting.enabled -}}
+true
+{{- end -}}
+{{- end -}}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
-	"reflect"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
@@ -31,49 +30,75 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
-	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-type reconcileError struct {
-	err     error
-	counter uint64
-}
+const (
+	APIVersion    = "core/v1"
+	SecretKind    = "Secret"
+	ConfigMapKind = "ConfigMap"
+)
 
 const (
-	APIVersion             = "core/v1"
-	SecretKind             = "Secret"
-	ConfigMapKind          = "ConfigMap"
-	containerProbeURI      = "login"
-	containerProbePortName = "http"
+	conditionReasonCompleted                   = "Completed"
+	conditionReasonInProgress                  = "InProgress"
+	conditionReasonValid                       = "Valid"
+	conditionReasonValidationFailed            = "ValidationFailed"
+	conditionReasonBaseConfigurationInvalid    = "BaseConfigurationInvalid"
+	conditionReasonUserConfigurationInvalid    = "UserConfigurationInvalid"
+	conditionReasonBaseConfigurationInProgress = "BaseConfigurationInProgress"
+	conditionReasonUserConfigurationInProgress = "UserConfigurationInProgress"
+	conditionReasonReconcileFailed             = "ReconcileFailed"
+	conditionReasonReconcileSucceeded          = "ReconcileSucceeded"
+	conditionReasonRepeatedReconcileFailure    = "RepeatedReconcileFailure"
+	conditionReasonReset                       = "Reset"
 )
 
-var reconcileErrors = map[string]reconcileError{}
+var reconcileErrors = newReconcileErrorTracker()
 var logx = log.Log
 
 // JenkinsReconciler reconciles a Jenkins object
 type JenkinsReconciler struct {
-	Client                       client.Client
+	Client client.Client
+	// APIReader reads objects directly from the API server, e.g. cluster scoped nodes which aren't watched
+	APIReader                    client.Reader
 	Scheme                       *runtime.Scheme
 	JenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
 	ClientSet                    kubernetes.Clientset
 	Config                       rest.Config
 	NotificationEvents           *chan event.Event
 	KubernetesClusterDomain      string
+	// NamespaceSelector limits reconciled Jenkins CRs to namespaces matching it when all namespaces are watched
+	NamespaceSelector labels.Selector
+	// MaxConcurrentReconciles is the maximum number of Jenkins CRs reconciled in parallel, defaults to 1
+	MaxConcurrentReconciles int
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -82,20 +107,55 @@ func (r *JenkinsReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	configMapResource := &source.Kind{Type: &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: ConfigMapKind}}}
 	secretResource := &source.Kind{Type: &corev1.Secret{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: SecretKind}}}
 	decorator := jenkinsDecorator{handler: &handler.EnqueueRequestForObject{}}
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha2.Jenkins{}).
 		Owns(&corev1.Pod{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&networkingv1.Ingress{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ConfigMap{}).
 		Watches(secretResource, jenkinsHandler).
 		Watches(configMapResource, jenkinsHandler).
 		Watches(&source.Kind{Type: &v1alpha2.Jenkins{}}, &decorator).
-		Complete(r)
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles})
+	// policy/v1beta1 has been removed in Kubernetes 1.25, watch the version served by the cluster
+	if gvk, found := resources.GetPodDisruptionBudgetGVK(&r.ClientSet); found {
+		podDisruptionBudget := &unstructured.Unstructured{}
+		podDisruptionBudget.SetGroupVersionKind(gvk)
+		builder = builder.Owns(podDisruptionBudget)
+	}
+	// reconcile Jenkins CRs when the labels of their namespace start or stop matching the namespace selector
+	if r.NamespaceSelector != nil && !r.NamespaceSelector.Empty() {
+		namespaceLabelsChanged := predicate.Funcs{
+			UpdateFunc: func(e ctrlevent.UpdateEvent) bool {
+				return !equality.Semantic.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+			},
+		}
+		builder = builder.Watches(&source.Kind{Type: &corev1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForNamespace), ctrlbuilder.WithPredicates(namespaceLabelsChanged))
+	}
+	return builder.Complete(r)
+}
+
+// requestsForNamespace returns the reconcile requests of all Jenkins CRs in the namespace
+func (r *JenkinsReconciler) requestsForNamespace(namespace client.Object) []reconcile.Request {
+	jenkinsList := &v1alpha2.JenkinsList{}
+	if err := r.Client.List(context.TODO(), jenkinsList, client.InNamespace(namespace.GetName())); err != nil {
+		logx.V(log.VWarn).Info(fmt.Sprintf("Failed to list Jenkins CRs in namespace '%s': %s", namespace.GetName(), err))
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, jenkins := range jenkinsList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name}})
+	}
+	return requests
 }
 
 func (r *JenkinsReconciler) newJenkinsReconcilier(jenkins *v1alpha2.Jenkins) configuration.Configuration {
 	config := configuration.Configuration{
 		Client:                       r.Client,
+		APIReader:                    r.APIReader,
 		ClientSet:                    r.ClientSet,
 		Notifications:                r.NotificationEvents,
 		Jenkins:                      jenkins,
@@ -121,63 +181,57 @@ func (r *JenkinsReconciler) newJenkinsReconcilier(jenkins *v1alpha2.Jenkins) con
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;watch;list;create;patch
 // +kubebuilder:rbac:groups=apps;jenkins-operator,resources=deployments/finalizers,verbs=update
 // +kubebuilder:rbac:groups=jenkins.io,resources=*,verbs=*
-// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// nodes are cluster scoped, the operator limited to namespaces needs a ClusterRole to get them
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams,verbs=get;list;watch
 // +kubebuilder:rbac:groups=build.openshift.io,resources=builds;buildconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;prometheusrules,verbs=get;list;watch;create;update;delete
 
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.7.0/pkg/reconcile
 func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (ctrl.Result, error) {
-	reconcileFailLimit := uint64(10)
 	logger := logx.WithValues("cr", request.Name)
 	logger.V(log.VDebug).Info("Reconciling Jenkins")
 
 	result, jenkins, err := r.reconcile(request)
 	if err != nil && apierrors.IsConflict(err) {
 		return reconcile.Result{Requeue: true}, nil
-	} else if err != nil {
-		lastErrors, found := reconcileErrors[request.Name]
-		if found {
-			if err.Error() == lastErrors.err.Error() {
-				lastErrors.counter++
-			} else {
-				lastErrors.counter = 1
-				lastErrors.err = err
-			}
-		} else {
-			lastErrors = reconcileError{
-				err:     err,
-				counter: 1,
-			}
-		}
-		reconcileErrors[request.Name] = lastErrors
-		if lastErrors.counter >= reconcileFailLimit {
+	}
+
+	lastError := reconcileErrors.record(request.NamespacedName, err)
+	if jenkins != nil {
+		r.updateStalledCondition(jenkins, lastError)
+	}
+	if err != nil {
+		if lastError.counter == reconcileFailLimit {
+			message := fmt.Sprintf("Reconcile loop failed %d times with the same errors, backing off: %s", reconcileFailLimit, err)
 			if log.Debug {
-				logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed %d times with the same errors, giving up: %+v", reconcileFailLimit, err))
+				logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed %d times with the same errors, backing off: %+v", reconcileFailLimit, err))
 			} else {
-				logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed %d times with the same errors, giving up: %s", reconcileFailLimit, err))
+				logger.V(log.VWarn).Info(message)
 			}
 
-			*r.NotificationEvents <- event.Event{
-				Jenkins: *jenkins,
-				Phase:   event.PhaseBase,
-				Level:   v1alpha2.NotificationLevelWarning,
-				Reason: reason.NewReconcileLoopFailed(
-					reason.OperatorSource,
-					[]string{fmt.Sprintf("Reconcile loop failed %d times with the same errors, giving up: %s", reconcileFailLimit, err)},
-				),
+			if jenkins != nil {
+				*r.NotificationEvents <- event.Event{
+					Jenkins: *jenkins,
+					Phase:   event.PhaseBase,
+					Level:   v1alpha2.NotificationLevelWarning,
+					Reason:  reason.NewReconcileLoopFailed(reason.OperatorSource, []string{message}),
+				}
 			}
-			return reconcile.Result{Requeue: false}, nil
-		}
-
-		if log.Debug {
+		} else if log.Debug {
 			logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed: %+v", err))
-		} else if err.Error() != fmt.Sprintf("Operation cannot be fulfilled on jenkins.jenkins.io \"%s\": the object has been modified; please apply your changes to the latest version and try again", request.Name) {
+		} else {
 			logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed: %s", err))
 		}
 
-		if groovyErr, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed); ok {
+		if groovyErr, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed); ok && jenkins != nil {
 			*r.NotificationEvents <- event.Event{
 				Jenkins: *jenkins,
 				Phase:   event.PhaseBase,
@@ -190,38 +244,100 @@ func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (
 			}
 			return reconcile.Result{Requeue: false}, nil
 		}
-		return reconcile.Result{Requeue: true}, nil
+		return reconcile.Result{RequeueAfter: lastError.backoff()}, nil
 	}
 	if result.Requeue && result.RequeueAfter == 0 {
 		result.RequeueAfter = time.Duration(rand.Intn(10)) * time.Millisecond
 	}
+	// Jenkins becoming idle, the maintenance window start or the node drain don't trigger any event
+	if jenkins != nil && !result.Requeue && result.RequeueAfter == 0 {
+		if jenkins.Status.PendingRestart != nil {
+			result.RequeueAfter = base.PendingRestartCheckInterval
+		} else if podDisruptionBudget := jenkins.Spec.Master.PodDisruptionBudget; podDisruptionBudget != nil && podDisruptionBudget.Enabled {
+			result.RequeueAfter = base.NodeDrainCheckInterval
+		}
+	}
 	return result, nil
 }
 
-func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Result, *v1alpha2.Jenkins, error) {
+// updateStalledCondition reports the Jenkins CR as stalled when the reconcile loop keeps failing with the same error
+func (r *JenkinsReconciler) updateStalledCondition(jenkins *v1alpha2.Jenkins, lastError reconcileError) {
+	observedConditions := append([]metav1.Condition{}, jenkins.Status.Conditions...)
+	if lastError.stalled() {
+		jenkins.SetCondition(v1alpha2.ConditionStalled, metav1.ConditionTrue, conditionReasonRepeatedReconcileFailure,
+			fmt.Sprintf("Reconcile loop failed at least %d times with the same error, retrying with backoff: %s", reconcileFailLimit, lastError.err))
+	} else if lastError.counter == 0 {
+		jenkins.SetCondition(v1alpha2.ConditionStalled, metav1.ConditionFalse, conditionReasonReconcileSucceeded, "Reconcile loop succeeded")
+	}
+	r.notifyRecovery(jenkins, observedConditions)
+	if equality.Semantic.DeepEqual(observedConditions, jenkins.Status.Conditions) {
+		return
+	}
+
+	if err := configuration.UpdateJenkinsStatus(r.Client, jenkins); err != nil && !apierrors.IsNotFound(err) {
+		logx.WithValues("cr", jenkins.Name).V(log.VWarn).Info(fmt.Sprintf("Failed to update stalled condition: %s", err))
+	}
+}
+
+func (r *JenkinsReconciler) reconcile(request reconcile.Request) (result reconcile.Result, jenkins *v1alpha2.Jenkins, err error) {
 	logger := logx.WithValues("cr", request.Name)
 	// Fetch the Jenkins instance
-	jenkins := &v1alpha2.Jenkins{}
-	var err error
+	jenkins = &v1alpha2.Jenkins{}
 	err = r.Client.Get(context.TODO(), request.NamespacedName, jenkins)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.StopRuntimeCollector(request.Namespace, request.Name)
+			metrics.DeleteCRMetrics(request.Namespace, request.Name)
 			return reconcile.Result{}, nil, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, nil, errors.WithStack(err)
 	}
-	var requeue bool
-	requeue, err = r.setDefaults(jenkins)
+	if !jenkins.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.finalize(jenkins)
+	}
+	var watched bool
+	watched, err = r.isNamespaceWatched(jenkins.Namespace)
 	if err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
-	if requeue {
-		return reconcile.Result{Requeue: true}, jenkins, nil
+	if !watched {
+		logger.V(log.VDebug).Info(fmt.Sprintf("Skipping, namespace '%s' doesn't match the namespace selector", jenkins.Namespace))
+		return reconcile.Result{}, jenkins, nil
+	}
+	if !controllerutil.ContainsFinalizer(jenkins, constants.JenkinsFinalizer) {
+		controllerutil.AddFinalizer(jenkins, constants.JenkinsFinalizer)
+		if err = r.Client.Update(context.TODO(), jenkins); err != nil {
+			return reconcile.Result{}, jenkins, errors.WithStack(err)
+		}
+	}
+
+	observedStatus := jenkins.Status.DeepCopy()
+	defer func() {
+		if statusErr := r.updateStatus(jenkins, observedStatus, err); statusErr != nil && err == nil {
+			err = statusErr
+		}
+	}()
+
+	if _, found := jenkins.Annotations[constants.ResetReconcileErrorsAnnotation]; found {
+		patch := client.MergeFrom(jenkins.DeepCopy())
+		delete(jenkins.Annotations, constants.ResetReconcileErrorsAnnotation)
+		if err = r.Client.Patch(context.TODO(), jenkins, patch); err != nil {
+			return reconcile.Result{}, jenkins, errors.WithStack(err)
+		}
+		reconcileErrors.reset(request.NamespacedName)
+		jenkins.SetCondition(v1alpha2.ConditionStalled, metav1.ConditionFalse, conditionReasonReset, "Reconcile errors have been reset")
+		logger.Info("Reconcile errors have been reset")
+	}
+
+	if len(jenkins.Spec.Master.Containers) > 0 && jenkins.Spec.Master.Containers[0].Name != resources.JenkinsMasterContainerName {
+		return reconcile.Result{}, jenkins, errors.Errorf("first container in spec.master.containers must be Jenkins container with name '%s', please correct CR", resources.JenkinsMasterContainerName)
 	}
+	// defaults are set by the mutating webhook, the operator sets them in memory only when it's disabled
+	jenkins.SetDefaults()
 
 	config := r.newJenkinsReconcilier(jenkins)
 	// Reconcile base configuration
@@ -244,26 +360,37 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 		for _, msg := range baseMessages {
 			logger.V(log.VWarn).Info(msg)
 		}
+		setValidationFailed(jenkins, conditionReasonBaseConfigurationInvalid, message, baseMessages)
 		return reconcile.Result{}, jenkins, nil // don't requeue
 	}
+	if condition := meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.ConditionValidationFailed); condition != nil &&
+		condition.Reason == conditionReasonBaseConfigurationInvalid {
+		jenkins.SetCondition(v1alpha2.ConditionValidationFailed, metav1.ConditionFalse, conditionReasonValid, "Base configuration is valid")
+		jenkins.Status.Validation = nil
+	}
 
-	var result reconcile.Result
 	var jenkinsClient jenkinsclient.Jenkins
+	baseStart := time.Now()
 	result, jenkinsClient, err = baseConfiguration.Reconcile()
+	metrics.ObserveReconcilePhase(jenkins, string(event.PhaseBase), baseStart)
 	if err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
-	if result.Requeue {
-		return result, jenkins, nil
-	}
-	if jenkinsClient == nil {
+	if result.Requeue || jenkinsClient == nil {
+		jenkins.SetCondition(v1alpha2.ConditionBaseConfigured, metav1.ConditionFalse, conditionReasonInProgress, "Base configuration phase is in progress")
+		jenkins.SetCondition(v1alpha2.ConditionReady, metav1.ConditionFalse, conditionReasonBaseConfigurationInProgress, "Base configuration phase is in progress")
+		if result.Requeue {
+			return result, jenkins, nil
+		}
 		return reconcile.Result{Requeue: false}, jenkins, nil
 	}
+	jenkins.SetCondition(v1alpha2.ConditionBaseConfigured, metav1.ConditionTrue, conditionReasonCompleted, "Base configuration phase is complete")
+	metrics.EnsureRuntimeCollector(jenkins, jenkinsClient)
 
 	if jenkins.Status.BaseConfigurationCompletedTime == nil {
 		now := metav1.Now()
 		jenkins.Status.BaseConfigurationCompletedTime = &now
-		err = r.Client.Status().Update(context.TODO(), jenkins)
+		err = configuration.UpdateJenkinsStatus(r.Client, jenkins)
 		if err != nil {
 			return reconcile.Result{}, jenkins, errors.WithStack(err)
 		}
@@ -300,8 +427,14 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 		for _, msg := range messages {
 			logger.V(log.VWarn).Info(msg)
 		}
+		setValidationFailed(jenkins, conditionReasonUserConfigurationInvalid, message, messages)
 		return reconcile.Result{}, jenkins, nil // don't requeue
 	}
+	jenkins.SetCondition(v1alpha2.ConditionValidationFailed, metav1.ConditionFalse, conditionReasonValid, "Jenkins CR is valid")
+	jenkins.Status.Validation = nil
+
+	userStart := time.Now()
+	defer metrics.ObserveReconcilePhase(jenkins, string(event.PhaseUser), userStart)
 
 	// Reconcile casc
 	result, err = userConfiguration.ReconcileCasc()
@@ -309,6 +442,7 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 		return reconcile.Result{}, jenkins, err
 	}
 	if result.Requeue {
+		setUserConfigurationInProgress(jenkins)
 		return result, jenkins, nil
 	}
 
@@ -318,13 +452,16 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 		return reconcile.Result{}, jenkins, err
 	}
 	if result.Requeue {
+		setUserConfigurationInProgress(jenkins)
 		return result, jenkins, nil
 	}
+	jenkins.SetCondition(v1alpha2.ConditionUserConfigured, metav1.ConditionTrue, conditionReasonCompleted, "User configuration phase is complete")
+	jenkins.SetCondition(v1alpha2.ConditionReady, metav1.ConditionTrue, conditionReasonCompleted, "Jenkins is configured and ready")
 
 	if jenkins.Status.UserConfigurationCompletedTime == nil {
 		now := metav1.Now()
 		jenkins.Status.UserConfigurationCompletedTime = &now
-		err = r.Client.Status().Update(context.TODO(), jenkins)
+		err = configuration.UpdateJenkinsStatus(r.Client, jenkins)
 		if err != nil {
 			return reconcile.Result{}, jenkins, errors.WithStack(err)
 		}
@@ -341,159 +478,132 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 	return reconcile.Result{}, jenkins, nil
 }
 
-func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
-	changed := false
-	logger := logx.WithValues("cr", jenkins.Name)
-
-	var jenkinsContainer v1alpha2.Container
-	if len(jenkins.Spec.Master.Containers) == 0 {
-		changed = true
-		jenkinsContainer = v1alpha2.Container{Name: resources.JenkinsMasterContainerName}
-	} else {
-		if jenkins.Spec.Master.Containers[0].Name != resources.JenkinsMasterContainerName {
-			return false, errors.Errorf("first container in spec.master.containers must be Jenkins container with name '%s', please correct CR", resources.JenkinsMasterContainerName)
-		}
-		jenkinsContainer = jenkins.Spec.Master.Containers[0]
-	}
-
-	if len(jenkinsContainer.Image) == 0 {
-		logger.Info("Setting default Jenkins master image: " + constants.DefaultJenkinsMasterImage)
-		changed = true
-		jenkinsContainer.Image = constants.DefaultJenkinsMasterImage
-		jenkinsContainer.ImagePullPolicy = corev1.PullAlways
-	}
-	if len(jenkinsContainer.ImagePullPolicy) == 0 {
-		logger.Info(fmt.Sprintf("Setting default Jenkins master image pull policy: %s", corev1.PullAlways))
-		changed = true
-		jenkinsContainer.ImagePullPolicy = corev1.PullAlways
-	}
-
-	if jenkinsContainer.ReadinessProbe == nil {
-		logger.Info("Setting default Jenkins readinessProbe")
-		changed = true
-		jenkinsContainer.ReadinessProbe = resources.NewProbe(containerProbeURI, containerProbePortName, corev1.URISchemeHTTP, 60, 1, 10)
-	}
-	if jenkinsContainer.LivenessProbe == nil {
-		logger.Info("Setting default Jenkins livenessProbe")
-		changed = true
-		jenkinsContainer.LivenessProbe = resources.NewProbe(containerProbeURI, containerProbePortName, corev1.URISchemeHTTP, 80, 5, 12)
-	}
-	if len(jenkinsContainer.Command) == 0 {
-		logger.Info("Setting default Jenkins container command")
-		changed = true
-		jenkinsContainer.Command = resources.GetJenkinsMasterContainerBaseCommand()
-	}
-	if isJavaOpsVariableNotSet(jenkinsContainer) {
-		logger.Info("Setting default Jenkins container JAVA_OPTS environment variable")
-		changed = true
-		jenkinsContainer.Env = append(jenkinsContainer.Env, corev1.EnvVar{
-			Name:  constants.JavaOpsVariableName,
-			Value: "-XX:MinRAMPercentage=50.0 -XX:MaxRAMPercentage=80.0 -Djenkins.install.runSetupWizard=false -Djava.awt.headless=true",
-		})
-	}
-	if len(jenkins.Spec.Master.BasePlugins) == 0 {
-		logger.Info("Setting default operator plugins")
-		changed = true
-		jenkins.Spec.Master.BasePlugins = basePlugins()
-	}
-	if isResourceRequirementsNotSet(jenkinsContainer.Resources) {
-		logger.Info("Setting default Jenkins master container resource requirements")
-		changed = true
-		jenkinsContainer.Resources = resources.NewResourceRequirements("1", "500Mi", "1500m", "3Gi")
-	}
-	if reflect.DeepEqual(jenkins.Spec.Service, v1alpha2.Service{}) {
-		logger.Info("Setting default Jenkins master service")
-		changed = true
-		var serviceType = corev1.ServiceTypeClusterIP
-		if r.JenkinsAPIConnectionSettings.UseNodePort {
-			serviceType = corev1.ServiceTypeNodePort
-		}
-		jenkins.Spec.Service = v1alpha2.Service{
-			Type: serviceType,
-			Port: constants.DefaultHTTPPortInt32,
-		}
+// isNamespaceWatched tells if Jenkins CRs from the namespace are reconciled
+func (r *JenkinsReconciler) isNamespaceWatched(namespace string) (bool, error) {
+	if r.NamespaceSelector == nil || r.NamespaceSelector.Empty() {
+		return true, nil
 	}
-	if reflect.DeepEqual(jenkins.Spec.SlaveService, v1alpha2.Service{}) {
-		logger.Info("Setting default Jenkins slave service")
-		changed = true
-		jenkins.Spec.SlaveService = v1alpha2.Service{
-			Type: corev1.ServiceTypeClusterIP,
-			Port: constants.DefaultSlavePortInt32,
-		}
-	}
-	if len(jenkins.Spec.Master.Containers) > 1 {
-		for i, container := range jenkins.Spec.Master.Containers[1:] {
-			if r.setDefaultsForContainer(jenkins, container.Name, i+1) {
-				changed = true
-			}
-		}
-	}
-	if len(jenkins.Spec.Backup.ContainerName) > 0 && jenkins.Spec.Backup.Interval == 0 {
-		logger.Info("Setting default backup interval")
-		changed = true
-		jenkins.Spec.Backup.Interval = 30
+	ns := &corev1.Namespace{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, errors.WithStack(err)
 	}
+	return r.NamespaceSelector.Matches(labels.Set(ns.Labels)), nil
+}
 
-	if len(jenkins.Spec.Master.Containers) == 0 || len(jenkins.Spec.Master.Containers) == 1 {
-		jenkins.Spec.Master.Containers = []v1alpha2.Container{jenkinsContainer}
-	} else {
-		noJenkinsContainers := jenkins.Spec.Master.Containers[1:]
-		containers := []v1alpha2.Container{jenkinsContainer}
-		containers = append(containers, noJenkinsContainers...)
-		jenkins.Spec.Master.Containers = containers
+// finalize cleans up after Jenkins CR deletion and removes the finalizer
+func (r *JenkinsReconciler) finalize(jenkins *v1alpha2.Jenkins) (reconcile.Result, *v1alpha2.Jenkins, error) {
+	if !controllerutil.ContainsFinalizer(jenkins, constants.JenkinsFinalizer) {
+		return reconcile.Result{}, jenkins, nil
 	}
+	logger := logx.WithValues("cr", jenkins.Name)
 
-	if reflect.DeepEqual(jenkins.Spec.JenkinsAPISettings, v1alpha2.JenkinsAPISettings{}) {
-		logger.Info("Setting default Jenkins API settings")
-		changed = true
-		jenkins.Spec.JenkinsAPISettings = v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy}
+	baseConfiguration := base.New(r.newJenkinsReconcilier(jenkins), r.JenkinsAPIConnectionSettings)
+	if err := baseConfiguration.Finalize(); err != nil {
+		return reconcile.Result{}, jenkins, err
 	}
+	metrics.StopRuntimeCollector(jenkins.Namespace, jenkins.Name)
+	metrics.DeleteCRMetrics(jenkins.Namespace, jenkins.Name)
 
-	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy == "" {
-		logger.Info("Setting default Jenkins API settings authorization strategy")
-		changed = true
-		jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy = v1alpha2.CreateUserAuthorizationStrategy
+	controllerutil.RemoveFinalizer(jenkins, constants.JenkinsFinalizer)
+	if err := r.Client.Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, jenkins, errors.WithStack(err)
 	}
 
-	if changed {
-		return changed, errors.WithStack(r.Client.Update(context.TODO(), jenkins))
+	message := "Jenkins has been deleted and cleaned up"
+	*r.NotificationEvents <- event.Event{
+		Jenkins: *jenkins,
+		Phase:   event.PhaseBase,
+		Level:   v1alpha2.NotificationLevelInfo,
+		Reason:  reason.NewJenkinsDeletion(reason.OperatorSource, []string{message}),
 	}
-	return changed, nil
+	logger.Info(message)
+	return reconcile.Result{}, jenkins, nil
 }
 
-func isJavaOpsVariableNotSet(container v1alpha2.Container) bool {
-	for _, env := range container.Env {
-		if env.Name == constants.JavaOpsVariableName {
-			return false
-		}
+func setValidationFailed(jenkins *v1alpha2.Jenkins, conditionReason, message string, messages []string) {
+	jenkins.SetCondition(v1alpha2.ConditionValidationFailed, metav1.ConditionTrue, conditionReason, message)
+	jenkins.SetCondition(v1alpha2.ConditionReady, metav1.ConditionFalse, conditionReasonValidationFailed, message)
+	jenkins.Status.Validation = &v1alpha2.ValidationStatus{
+		ObservedGeneration: jenkins.Generation,
+		Messages:           messages,
 	}
-	return true
 }
 
-func (r *JenkinsReconciler) setDefaultsForContainer(jenkins *v1alpha2.Jenkins, containerName string, containerIndex int) bool {
-	changed := false
-	logger := logx.WithValues("cr", jenkins.Name, "container", containerName)
+func setUserConfigurationInProgress(jenkins *v1alpha2.Jenkins) {
+	jenkins.SetCondition(v1alpha2.ConditionUserConfigured, metav1.ConditionFalse, conditionReasonInProgress, "User configuration phase is in progress")
+	jenkins.SetCondition(v1alpha2.ConditionReady, metav1.ConditionFalse, conditionReasonUserConfigurationInProgress, "User configuration phase is in progress")
+}
 
-	if len(jenkins.Spec.Master.Containers[containerIndex].ImagePullPolicy) == 0 {
-		logger.Info(fmt.Sprintf("Setting default container image pull policy: %s", corev1.PullAlways))
-		changed = true
-		jenkins.Spec.Master.Containers[containerIndex].ImagePullPolicy = corev1.PullAlways
+// updateStatus sets the Degraded condition according to the reconcile loop error, notifies about the recovery
+// from reported failures and persists conditions, validation messages and the next maintenance window if they differ from the observed ones
+func (r *JenkinsReconciler) updateStatus(jenkins *v1alpha2.Jenkins, observedStatus *v1alpha2.JenkinsStatus, reconcileErr error) error {
+	if reconcileErr != nil && apierrors.IsConflict(reconcileErr) {
+		return nil // the CR is outdated, conditions will be updated in the next reconcile loop
 	}
-	if isResourceRequirementsNotSet(jenkins.Spec.Master.Containers[containerIndex].Resources) {
-		logger.Info("Setting default container resource requirements")
-		changed = true
-		jenkins.Spec.Master.Containers[containerIndex].Resources = resources.NewResourceRequirements("50m", "50Mi", "100m", "100Mi")
+	if reconcileErr != nil {
+		jenkins.SetCondition(v1alpha2.ConditionDegraded, metav1.ConditionTrue, conditionReasonReconcileFailed, reconcileErr.Error())
+	} else {
+		jenkins.SetCondition(v1alpha2.ConditionDegraded, metav1.ConditionFalse, conditionReasonReconcileSucceeded, "Reconcile loop succeeded")
 	}
-	return changed
+	r.notifyRecovery(jenkins, observedStatus.Conditions)
+
+	if equality.Semantic.DeepEqual(observedStatus.Conditions, jenkins.Status.Conditions) &&
+		equality.Semantic.DeepEqual(observedStatus.Validation, jenkins.Status.Validation) &&
+		equality.Semantic.DeepEqual(observedStatus.NextMaintenanceWindow, jenkins.Status.NextMaintenanceWindow) {
+		return nil
+	}
+	return errors.WithStack(configuration.UpdateJenkinsStatus(r.Client, jenkins))
 }
 
-func isResourceRequirementsNotSet(requirements corev1.ResourceRequirements) bool {
-	return reflect.DeepEqual(requirements, corev1.ResourceRequirements{})
+// notifyRecovery sends the phase complete notification when a failure, which has been reported by a warning
+// notification, is gone, e.g. to resolve PagerDuty incidents triggered by the stalled reconcile loop or validation failures
+func (r *JenkinsReconciler) notifyRecovery(jenkins *v1alpha2.Jenkins, observedConditions []metav1.Condition) {
+	var baseMessages []string
+	// ReconcileLoopFailed is sent when the reconcile loop becomes stalled
+	if hasConditionStatusRecovered(observedConditions, jenkins.Status.Conditions, v1alpha2.ConditionStalled, "") {
+		baseMessages = append(baseMessages, "Reconcile loop has recovered from repeated failures")
+	}
+	if hasConditionStatusRecovered(observedConditions, jenkins.Status.Conditions, v1alpha2.ConditionValidationFailed, conditionReasonBaseConfigurationInvalid) {
+		baseMessages = append(baseMessages, "Base configuration is valid again")
+	}
+	if len(baseMessages) > 0 {
+		*r.NotificationEvents <- event.Event{
+			Jenkins: *jenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelInfo,
+			Reason:  reason.NewBaseConfigurationComplete(reason.OperatorSource, baseMessages),
+		}
+		for _, message := range baseMessages {
+			logx.WithValues("cr", jenkins.Name).Info(message)
+		}
+	}
+
+	if hasConditionStatusRecovered(observedConditions, jenkins.Status.Conditions, v1alpha2.ConditionValidationFailed, conditionReasonUserConfigurationInvalid) {
+		message := "User configuration is valid again"
+		*r.NotificationEvents <- event.Event{
+			Jenkins: *jenkins,
+			Phase:   event.PhaseUser,
+			Level:   v1alpha2.NotificationLevelInfo,
+			Reason:  reason.NewUserConfigurationComplete(reason.OperatorSource, []string{message}),
+		}
+		logx.WithValues("cr", jenkins.Name).Info(message)
+	}
 }
 
-func basePlugins() (result []v1alpha2.Plugin) {
-	for _, value := range plugins.BasePlugins() {
-		result = append(result, v1alpha2.Plugin{Name: value.Name, Version: value.Version})
+// hasConditionStatusRecovered tells if the failure condition, optionally with the given reason, was true
+// and isn't anymore
+func hasConditionStatusRecovered(observed, current []metav1.Condition, conditionType, conditionReason string) bool {
+	observedCondition := meta.FindStatusCondition(observed, conditionType)
+	if observedCondition == nil || observedCondition.Status != metav1.ConditionTrue {
+		return false
+	}
+	if len(conditionReason) > 0 && observedCondition.Reason != conditionReason {
+		return false
+	}
+
+	currentCondition := meta.FindStatusCondition(current, conditionType)
+	if currentCondition == nil || currentCondition.Status != metav1.ConditionTrue {
+		return true
 	}
-	return
+	return len(conditionReason) > 0 && currentCondition.Reason != conditionReason
 }
@@ -0,0 +1,254 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/pagerduty"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestJenkinsReconciler_ReconcileConcurrently should be run with the race detector, go test -race
+func TestJenkinsReconciler_ReconcileConcurrently(t *testing.T) {
+	const (
+		crs        = 5
+		iterations = 3
+	)
+
+	// API server supports only the core API, the Route and Prometheus Operator APIs aren't available
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api":
+			_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"]}`))
+		case "/apis":
+			_, _ = w.Write([]byte(`{"kind":"APIGroupList","groups":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer apiServer.Close()
+	config := rest.Config{Host: apiServer.URL}
+	clientSet, err := kubernetes.NewForConfig(&config)
+	require.NoError(t, err)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	notificationEvents := make(chan event.Event)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-notificationEvents:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	reconciler := &JenkinsReconciler{
+		Client:                  fakeClient,
+		Scheme:                  scheme,
+		ClientSet:               *clientSet,
+		Config:                  config,
+		NotificationEvents:      &notificationEvents,
+		KubernetesClusterDomain: "cluster.local",
+	}
+
+	var requests []ctrl.Request
+	for i := 0; i < crs; i++ {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("jenkins-%d", i),
+				Namespace: fmt.Sprintf("namespace-%d", i%2),
+			},
+			Spec: v1alpha2.JenkinsSpec{
+				Backup: v1alpha2.Backup{
+					ContainerName: "backup",
+					Action:        v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"backup"}}},
+					Interval:      3600,
+				},
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{
+						{Name: constants.JenkinsMasterContainerName},
+						{Name: "backup", Image: "backup"},
+					},
+				},
+			},
+		}
+		require.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+		requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name}})
+	}
+
+	// like the controller, reconcile different CRs in parallel and the same CR sequentially
+	var wg sync.WaitGroup
+	for _, request := range requests {
+		wg.Add(1)
+		go func(request ctrl.Request) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				assert.NoError(t, err)
+			}
+
+			jenkins := &v1alpha2.Jenkins{}
+			if assert.NoError(t, fakeClient.Get(context.TODO(), request.NamespacedName, jenkins)) {
+				backupAndRestore := backuprestore.New(reconciler.newJenkinsReconcilier(jenkins), logx)
+				assert.NoError(t, backupAndRestore.EnsureBackupTrigger())
+				assert.True(t, backupAndRestore.IsBackupTriggerEnabled())
+				backupAndRestore.StopBackupTrigger()
+			}
+		}(request)
+	}
+	wg.Wait()
+
+	for _, request := range requests {
+		jenkins := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), request.NamespacedName, jenkins))
+		assert.Contains(t, jenkins.Finalizers, constants.JenkinsFinalizer)
+
+		pod := &corev1.Pod{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: request.Namespace, Name: "jenkins-" + request.Name}, pod))
+
+		reconcileErrors.reset(request.NamespacedName)
+	}
+}
+
+func TestJenkinsReconciler_updateStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(jenkins).Build()
+	notificationEvents := make(chan event.Event, 10)
+	reconciler := &JenkinsReconciler{Client: fakeClient, Scheme: scheme, NotificationEvents: &notificationEvents}
+
+	t.Run("single failure doesn't notify about the recovery", func(t *testing.T) {
+		observedStatus := jenkins.Status.DeepCopy()
+		require.NoError(t, reconciler.updateStatus(jenkins, observedStatus, errors.New("failure")))
+		reconciler.updateStalledCondition(jenkins, reconcileError{err: errors.New("failure"), counter: 1})
+
+		observedStatus = jenkins.Status.DeepCopy()
+		require.NoError(t, reconciler.updateStatus(jenkins, observedStatus, nil))
+		reconciler.updateStalledCondition(jenkins, reconcileError{})
+
+		assert.Empty(t, notificationEvents)
+	})
+	t.Run("stalled reconcile loop failure is resolved by the recovery", func(t *testing.T) {
+		lastError := reconcileError{err: errors.New("failure"), counter: reconcileFailLimit}
+		require.NoError(t, reconciler.updateStatus(jenkins, jenkins.Status.DeepCopy(), lastError.err))
+		reconciler.updateStalledCondition(jenkins, lastError)
+		assert.Empty(t, notificationEvents)
+		// sent by Reconcile when the reconcile loop becomes stalled
+		failure := event.Event{Jenkins: *jenkins, Phase: event.PhaseBase, Level: v1alpha2.NotificationLevelWarning}
+
+		require.NoError(t, reconciler.updateStatus(jenkins, jenkins.Status.DeepCopy(), nil))
+		reconciler.updateStalledCondition(jenkins, reconcileError{})
+
+		require.Len(t, notificationEvents, 1)
+		recovery := <-notificationEvents
+		assert.True(t, pagerduty.IsResolveReason(recovery.Reason))
+		assert.Equal(t, pagerduty.DedupKey(failure), pagerduty.DedupKey(recovery))
+	})
+	t.Run("success without failure doesn't notify", func(t *testing.T) {
+		observedStatus := jenkins.Status.DeepCopy()
+		require.NoError(t, reconciler.updateStatus(jenkins, observedStatus, nil))
+		reconciler.updateStalledCondition(jenkins, reconcileError{})
+
+		assert.Empty(t, notificationEvents)
+	})
+	t.Run("user configuration becomes valid", func(t *testing.T) {
+		setValidationFailed(jenkins, conditionReasonUserConfigurationInvalid, "invalid", []string{"invalid"})
+		require.NoError(t, reconciler.updateStatus(jenkins, jenkins.Status.DeepCopy(), nil))
+		observedStatus := jenkins.Status.DeepCopy()
+		jenkins.SetCondition(v1alpha2.ConditionValidationFailed, metav1.ConditionFalse, conditionReasonValid, "Jenkins CR is valid")
+		jenkins.Status.Validation = nil
+
+		require.NoError(t, reconciler.updateStatus(jenkins, observedStatus, nil))
+
+		require.Len(t, notificationEvents, 1)
+		recovery := <-notificationEvents
+		assert.Equal(t, event.PhaseUser, recovery.Phase)
+		assert.True(t, pagerduty.IsResolveReason(recovery.Reason))
+	})
+	t.Run("next maintenance window is persisted", func(t *testing.T) {
+		observedStatus := jenkins.Status.DeepCopy()
+		jenkins.Status.NextMaintenanceWindow = &v1alpha2.MaintenanceWindowStatus{
+			Start: metav1.NewTime(time.Date(2021, 3, 6, 22, 0, 0, 0, time.UTC)),
+			End:   metav1.NewTime(time.Date(2021, 3, 7, 2, 0, 0, 0, time.UTC)),
+		}
+
+		require.NoError(t, reconciler.updateStatus(jenkins, observedStatus, nil))
+
+		persisted := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, persisted))
+		assert.NotNil(t, persisted.Status.NextMaintenanceWindow)
+	})
+}
+
+func TestJenkinsReconciler_NamespaceSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha2.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "watched", Labels: map[string]string{"jenkins": "enabled"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ignored"}},
+		&v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "ignored"}},
+		&v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "watched"}},
+		&v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "watched"}},
+	).Build()
+	selector, err := labels.Parse("jenkins=enabled")
+	require.NoError(t, err)
+	reconciler := &JenkinsReconciler{Client: fakeClient, Scheme: scheme, NamespaceSelector: selector}
+
+	t.Run("skip CR in a namespace not matching the selector", func(t *testing.T) {
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ignored", Name: "jenkins"}}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+
+		require.NoError(t, err)
+		jenkins := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), request.NamespacedName, jenkins))
+		assert.NotContains(t, jenkins.Finalizers, constants.JenkinsFinalizer)
+	})
+	t.Run("namespace matching the selector", func(t *testing.T) {
+		watched, err := reconciler.isNamespaceWatched("watched")
+
+		require.NoError(t, err)
+		assert.True(t, watched)
+	})
+	t.Run("requests for namespace", func(t *testing.T) {
+		requests := reconciler.requestsForNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "watched"}})
+
+		assert.ElementsMatch(t, []ctrl.Request{
+			{NamespacedName: types.NamespacedName{Namespace: "watched", Name: "first"}},
+			{NamespacedName: types.NamespacedName{Namespace: "watched", Name: "second"}},
+		}, requests)
+	})
+}
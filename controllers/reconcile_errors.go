@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// reconcileFailLimit is the number of the same consecutive errors after which the Jenkins CR is reported as stalled
+	reconcileFailLimit = uint64(10)
+
+	reconcileInitialBackoff = time.Second
+	reconcileMaxBackoff     = 5 * time.Minute
+)
+
+type reconcileError struct {
+	err     error
+	counter uint64
+}
+
+// reconcileErrorTracker counts the same consecutive reconcile loop errors for each Jenkins CR
+type reconcileErrorTracker struct {
+	mutex  sync.Mutex
+	errors map[types.NamespacedName]reconcileError
+}
+
+func newReconcileErrorTracker() *reconcileErrorTracker {
+	return &reconcileErrorTracker{errors: map[types.NamespacedName]reconcileError{}}
+}
+
+// record stores the reconcile loop result and returns the error with the number of its consecutive occurrences,
+// a successful reconcile loop (nil error) resets the counter
+func (t *reconcileErrorTracker) record(key types.NamespacedName, err error) reconcileError {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if err == nil {
+		delete(t.errors, key)
+		return reconcileError{}
+	}
+
+	lastError, found := t.errors[key]
+	if found && err.Error() == lastError.err.Error() {
+		lastError.counter++
+	} else {
+		lastError = reconcileError{err: err, counter: 1}
+	}
+	t.errors[key] = lastError
+	return lastError
+}
+
+func (t *reconcileErrorTracker) reset(key types.NamespacedName) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.errors, key)
+}
+
+// stalled tells if the same error occurred at least reconcileFailLimit times in a row
+func (e reconcileError) stalled() bool {
+	return e.counter >= reconcileFailLimit
+}
+
+// backoff returns the exponential delay before the next reconcile loop
+func (e reconcileError) backoff() time.Duration {
+	backoff := reconcileInitialBackoff
+	for i := uint64(1); i < e.counter; i++ {
+		backoff *= 2
+		if backoff >= reconcileMaxBackoff {
+			return reconcileMaxBackoff
+		}
+	}
+	return backoff
+}
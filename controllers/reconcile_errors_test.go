@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcileErrorTracker(t *testing.T) {
+	first := types.NamespacedName{Namespace: "first", Name: "jenkins"}
+	second := types.NamespacedName{Namespace: "second", Name: "jenkins"}
+
+	t.Run("same errors are counted per namespaced name", func(t *testing.T) {
+		tracker := newReconcileErrorTracker()
+
+		tracker.record(first, errors.New("failure"))
+		lastError := tracker.record(first, errors.New("failure"))
+		otherError := tracker.record(second, errors.New("failure"))
+
+		assert.Equal(t, uint64(2), lastError.counter)
+		assert.Equal(t, uint64(1), otherError.counter)
+	})
+	t.Run("different error restarts counter", func(t *testing.T) {
+		tracker := newReconcileErrorTracker()
+
+		tracker.record(first, errors.New("failure"))
+		lastError := tracker.record(first, errors.New("another failure"))
+
+		assert.Equal(t, uint64(1), lastError.counter)
+		assert.EqualError(t, lastError.err, "another failure")
+	})
+	t.Run("success and reset clear counter", func(t *testing.T) {
+		tracker := newReconcileErrorTracker()
+
+		tracker.record(first, errors.New("failure"))
+		assert.Equal(t, uint64(0), tracker.record(first, nil).counter)
+		tracker.record(second, errors.New("failure"))
+		tracker.reset(second)
+
+		assert.Equal(t, uint64(1), tracker.record(second, errors.New("failure")).counter)
+	})
+	t.Run("stalled", func(t *testing.T) {
+		tracker := newReconcileErrorTracker()
+
+		var lastError reconcileError
+		for i := uint64(0); i < reconcileFailLimit; i++ {
+			assert.False(t, lastError.stalled())
+			lastError = tracker.record(first, errors.New("failure"))
+		}
+
+		assert.True(t, lastError.stalled())
+	})
+}
+
+func TestReconcileError_backoff(t *testing.T) {
+	assert.Equal(t, time.Second, reconcileError{counter: 1}.backoff())
+	assert.Equal(t, 2*time.Second, reconcileError{counter: 2}.backoff())
+	assert.Equal(t, 8*time.Second, reconcileError{counter: 4}.backoff())
+	assert.Equal(t, reconcileMaxBackoff, reconcileError{counter: 100}.backoff())
+}
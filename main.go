@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	r "runtime"
+	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/controllers"
@@ -36,10 +37,12 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -59,6 +62,19 @@ var (
 	logger            = logf.Log.WithName("cmd")
 )
 
+// parseWatchNamespaces returns the comma-separated list of unique namespaces, empty list means all namespaces
+func parseWatchNamespaces(watchNamespace string) []string {
+	var namespaces []string
+	seen := map[string]bool{}
+	for _, namespace := range strings.Split(watchNamespace, ",") {
+		if namespace = strings.TrimSpace(namespace); len(namespace) > 0 && !seen[namespace] {
+			seen[namespace] = true
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
 func printInfo() {
 	logger.Info(fmt.Sprintf("Version: %s", version.Version))
 	logger.Info(fmt.Sprintf("Git commit: %s", version.GitCommit))
@@ -79,6 +95,7 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var validateSecurityWarnings bool
+	var enableDefaultingWebhook bool
 
 	isRunningInCluster, err := resources.IsRunningInCluster()
 	if err != nil {
@@ -90,10 +107,13 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", isRunningInCluster, "Enable leader election for controller manager. "+
 		"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&validateSecurityWarnings, "validate-security-warnings", false, "Enable validation for potential security warnings in jenkins custom resource plugins")
+	flag.BoolVar(&enableDefaultingWebhook, "enable-defaulting-webhook", false, "Enable the mutating webhook which sets defaults of the jenkins custom resource spec")
 	hostname := flag.String("jenkins-api-hostname", "", "Hostname or IP of Jenkins API. It can be service name, node IP or localhost.")
 	port := flag.Int("jenkins-api-port", 0, "The port on which Jenkins API is running. Note: If you want to use nodePort don't set this setting and --jenkins-api-use-nodeport must be true.")
 	useNodePort := flag.Bool("jenkins-api-use-nodeport", false, "Connect to Jenkins API using the service nodePort instead of service port. If you want to set this as true - don't set --jenkins-api-port.")
 	kubernetesClusterDomain := flag.String("cluster-domain", "cluster.local", "Use custom domain name instead of 'cluster.local'.")
+	maxConcurrentReconciles := flag.Int("max-concurrent-reconciles", 1, "Maximum number of Jenkins CRs which can be reconciled in parallel.")
+	watchNamespaceSelector := flag.String("watch-namespace-selector", "", "Label selector of namespaces in which Jenkins CRs are reconciled. It requires watching all namespaces - WATCH_NAMESPACE set to empty string.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -105,11 +125,27 @@ func main() {
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 	printInfo()
 
-	namespace, found := os.LookupEnv("WATCH_NAMESPACE")
+	watchNamespace, found := os.LookupEnv("WATCH_NAMESPACE")
 	if !found {
 		fatal(errors.New("failed to get watch namespace, please set up WATCH_NAMESPACE environment variable"), *debug)
 	}
-	logger.Info(fmt.Sprintf("Watch namespace: %v", namespace))
+	namespaces := parseWatchNamespaces(watchNamespace)
+	if len(namespaces) == 0 {
+		logger.Info("Watch all namespaces")
+	} else {
+		logger.Info(fmt.Sprintf("Watch namespaces: %v", namespaces))
+	}
+	var namespaceSelector labels.Selector
+	if len(*watchNamespaceSelector) > 0 {
+		if len(namespaces) > 0 {
+			fatal(errors.New("--watch-namespace-selector requires watching all namespaces, please set WATCH_NAMESPACE environment variable to empty string"), *debug)
+		}
+		namespaceSelector, err = labels.Parse(*watchNamespaceSelector)
+		if err != nil {
+			fatal(errors.Wrap(err, "invalid --watch-namespace-selector"), *debug)
+		}
+		logger.Info(fmt.Sprintf("Watch namespaces matching selector: %s", namespaceSelector))
+	}
 
 	if validateSecurityWarnings {
 		securityWarningsFetched := make(chan bool)
@@ -126,15 +162,20 @@ func main() {
 		fatal(errors.Wrap(err, "failed to get config"), *debug)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	options := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     fmt.Sprintf("%s:%d", metricsHost, metricsPort),
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "c674355f.jenkins.io",
-		Namespace:              namespace,
-	})
+	}
+	if len(namespaces) == 1 {
+		options.Namespace = namespaces[0]
+	} else if len(namespaces) > 1 {
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		fatal(errors.Wrap(err, "unable to start manager"), *debug)
 	}
@@ -159,10 +200,17 @@ func main() {
 
 	// validate jenkins API connection
 	jenkinsAPIConnectionSettings := client.JenkinsAPIConnectionSettings{Hostname: *hostname, Port: *port, UseNodePort: *useNodePort}
+	if *useNodePort {
+		v1alpha2.DefaultServiceType = corev1.ServiceTypeNodePort
+	}
 	if err := jenkinsAPIConnectionSettings.Validate(); err != nil {
 		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
 	}
 
+	if *maxConcurrentReconciles < 1 {
+		fatal(errors.New("max concurrent reconciles must be greater than 0"), *debug)
+	}
+
 	// validate kubernetes cluster domain
 	if *kubernetesClusterDomain == "" {
 		fatal(errors.Wrap(err, "Kubernetes cluster domain can't be empty"), *debug)
@@ -170,12 +218,15 @@ func main() {
 
 	if err = (&controllers.JenkinsReconciler{
 		Client:                       mgr.GetClient(),
+		APIReader:                    mgr.GetAPIReader(),
 		Scheme:                       mgr.GetScheme(),
 		JenkinsAPIConnectionSettings: jenkinsAPIConnectionSettings,
 		ClientSet:                    *clientSet,
 		Config:                       *cfg,
 		NotificationEvents:           &notificationEvents,
 		KubernetesClusterDomain:      *kubernetesClusterDomain,
+		NamespaceSelector:            namespaceSelector,
+		MaxConcurrentReconciles:      *maxConcurrentReconciles,
 	}).SetupWithManager(mgr); err != nil {
 		fatal(errors.Wrap(err, "unable to create Jenkins controller"), *debug)
 	}
@@ -185,6 +236,11 @@ func main() {
 			fatal(errors.Wrap(err, "unable to create Webhook"), *debug)
 		}
 	}
+	if enableDefaultingWebhook {
+		if err = (&v1alpha2.Jenkins{}).SetupDefaultingWebhookWithManager(mgr); err != nil {
+			fatal(errors.Wrap(err, "unable to create defaulting Webhook"), *debug)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
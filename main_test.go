@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWatchNamespaces(t *testing.T) {
+	tests := []struct {
+		name           string
+		watchNamespace string
+		want           []string
+	}{
+		{name: "all namespaces", watchNamespace: "", want: nil},
+		{name: "single namespace", watchNamespace: "jenkins", want: []string{"jenkins"}},
+		{name: "multiple namespaces", watchNamespace: "jenkins,ci", want: []string{"jenkins", "ci"}},
+		{name: "spaces", watchNamespace: " jenkins , ci ", want: []string{"jenkins", "ci"}},
+		{name: "empty items", watchNamespace: " , jenkins,,", want: []string{"jenkins"}},
+		{name: "duplicates", watchNamespace: "jenkins,ci,jenkins", want: []string{"jenkins", "ci"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseWatchNamespaces(tt.watchNamespace))
+		})
+	}
+}
@@ -5,17 +5,19 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
-	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -26,10 +28,14 @@ type backupTrigger struct {
 }
 
 type backupTriggers struct {
+	mutex    sync.Mutex
 	triggers map[string]backupTrigger
 }
 
 func (t *backupTriggers) stop(logger logr.Logger, namespace string, name string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
 	key := t.key(namespace, name)
 	trigger, found := t.triggers[key]
 	if found {
@@ -42,6 +48,9 @@ func (t *backupTriggers) stop(logger logr.Logger, namespace string, name string)
 }
 
 func (t *backupTriggers) get(namespace, name string) (backupTrigger, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
 	trigger, found := t.triggers[t.key(namespace, name)]
 	return trigger, found
 }
@@ -51,10 +60,13 @@ func (t *backupTriggers) key(namespace, name string) string {
 }
 
 func (t *backupTriggers) add(namespace string, name string, trigger backupTrigger) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
 	t.triggers[t.key(namespace, name)] = trigger
 }
 
-var triggers = backupTriggers{triggers: make(map[string]backupTrigger)}
+var triggers = &backupTriggers{triggers: make(map[string]backupTrigger)}
 
 // BackupAndRestore represents Jenkins backup and restore client
 type BackupAndRestore struct {
@@ -132,12 +144,16 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 		bar.logger.V(log.VDebug).Info("Skipping restore backup")
 		if jenkins.Status.PendingBackup == 0 {
 			jenkins.Status.PendingBackup = 1
-			return bar.Client.Status().Update(context.TODO(), jenkins)
+			return configuration.UpdateJenkinsStatus(bar.Client, jenkins)
 		}
 		return nil
 	}
 
-	podName := resources.GetJenkinsMasterPodName(jenkins)
+	pod, err := bar.GetJenkinsMasterPod()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	podName := pod.Name
 	var backupNumber = jenkins.Status.LastBackup
 
 	if jenkins.Spec.Restore.GetLatestAction.Exec != nil {
@@ -152,7 +168,7 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 			bar.logger.V(log.VDebug).Info("Skipping restore backup, get latest action returned -1")
 			jenkins.Status.LastBackup = 0
 			jenkins.Status.PendingBackup = 1
-			return bar.Client.Status().Update(context.TODO(), jenkins)
+			return configuration.UpdateJenkinsStatus(bar.Client, jenkins)
 		}
 
 		backupNumber, err = strconv.ParseUint(backupNumberString, 10, 64)
@@ -173,7 +189,9 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 	bar.logger.Info(fmt.Sprintf("Restoring backup '%d'", backupNumber))
 	command := jenkins.Spec.Restore.Action.Exec.Command
 	command = append(command, fmt.Sprintf("%d", backupNumber))
-	_, _, err := bar.Exec(podName, jenkins.Spec.Restore.ContainerName, command)
+	start := time.Now()
+	_, _, err = bar.Exec(podName, jenkins.Spec.Restore.ContainerName, command)
+	metrics.ObserveRestore(jenkins, start, err)
 
 	if err == nil {
 		_, err := jenkinsClient.ExecuteScript("Jenkins.instance.reload()")
@@ -181,8 +199,9 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 			return err
 		}
 		//TODO fix me because we're doing two saves unatomically
+		patch := k8s.MergeFrom(jenkins.DeepCopy())
 		jenkins.Spec.Restore.RecoveryOnce = 0
-		err = bar.Client.Update(context.TODO(), jenkins)
+		err = bar.Client.Patch(context.TODO(), jenkins, patch) // don't write spec defaults set in memory
 		if err != nil {
 			return err
 		}
@@ -194,11 +213,12 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 		if err != nil {
 			return err
 		}
+		jenkins.SetDefaults()
 		bar.Configuration.Jenkins = jenkins
 
 		jenkins.Status.RestoredBackup = backupNumber
 		jenkins.Status.PendingBackup = backupNumber + 1
-		return bar.Client.Status().Update(context.TODO(), jenkins)
+		return configuration.UpdateJenkinsStatus(bar.Client, jenkins)
 	}
 
 	return err
@@ -217,22 +237,30 @@ func (bar *BackupAndRestore) Backup(setBackupDoneBeforePodDeletion bool) error {
 	}
 	backupNumber := jenkins.Status.PendingBackup
 	bar.logger.Info(fmt.Sprintf("Performing backup '%d'", backupNumber))
-	podName := resources.GetJenkinsMasterPodName(jenkins)
+	pod, err := bar.GetJenkinsMasterPod()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	podName := pod.Name
 	command := jenkins.Spec.Backup.Action.Exec.Command
 	command = append(command, fmt.Sprintf("%d", backupNumber))
-	_, _, err := bar.Exec(podName, jenkins.Spec.Backup.ContainerName, command)
+	start := time.Now()
+	_, _, err = bar.Exec(podName, jenkins.Spec.Backup.ContainerName, command)
+	metrics.ObserveBackup(jenkins, start, err)
 
 	if err == nil {
 		bar.logger.V(log.VDebug).Info(fmt.Sprintf("Backup completed '%d', updating status", backupNumber))
+		jenkins.SetCondition(v1alpha2.ConditionBackupHealthy, metav1.ConditionTrue, "BackupSucceeded", fmt.Sprintf("Backup '%d' completed", backupNumber))
 		if jenkins.Status.RestoredBackup == 0 {
 			jenkins.Status.RestoredBackup = backupNumber
 		}
 		jenkins.Status.LastBackup = backupNumber
 		jenkins.Status.PendingBackup = backupNumber
 		jenkins.Status.BackupDoneBeforePodDeletion = setBackupDoneBeforePodDeletion
-		return bar.Client.Status().Update(context.TODO(), jenkins)
+		return configuration.UpdateJenkinsStatus(bar.Client, jenkins)
 	}
 
+	jenkins.SetCondition(v1alpha2.ConditionBackupHealthy, metav1.ConditionFalse, "BackupFailed", fmt.Sprintf("Backup '%d' failed: %s", backupNumber, err))
 	return err
 }
 
@@ -248,7 +276,7 @@ func triggerBackup(ticker *time.Ticker, k8sClient k8s.Client, logger logr.Logger
 		}
 		if jenkins.Status.LastBackup == jenkins.Status.PendingBackup {
 			jenkins.Status.PendingBackup++
-			err = k8sClient.Status().Update(context.TODO(), jenkins)
+			err = configuration.UpdateJenkinsStatus(k8sClient, jenkins)
 			if err != nil {
 				logger.V(log.VWarn).Info(fmt.Sprintf("backup trigger, error when updating CR: %s", err))
 			}
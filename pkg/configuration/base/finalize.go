@@ -0,0 +1,103 @@
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Finalize cleans up after Jenkins CR deletion everything which isn't garbage collected by Kubernetes
+func (r *JenkinsBaseConfigurationReconciler) Finalize() error {
+	backupAndRestore := backuprestore.New(r.Configuration, r.logger)
+	backupAndRestore.StopBackupTrigger()
+
+	if r.Configuration.Jenkins.Spec.Backup.MakeBackupBeforeDeletion {
+		if err := r.makeBackupBeforeDeletion(backupAndRestore); err != nil {
+			return err
+		}
+	}
+
+	for _, customization := range []v1alpha2.Customization{
+		r.Configuration.Jenkins.Spec.GroovyScripts.Customization,
+		r.Configuration.Jenkins.Spec.ConfigurationAsCode.Customization,
+	} {
+		if err := r.removeLabelForWatchesResources(customization); err != nil {
+			return err
+		}
+	}
+
+	return r.deleteExtraRoleBindings(resources.NewResourceObjectMeta(r.Configuration.Jenkins))
+}
+
+func (r *JenkinsBaseConfigurationReconciler) makeBackupBeforeDeletion(backupAndRestore *backuprestore.BackupAndRestore) error {
+	jenkins := r.Configuration.Jenkins
+	pod, err := r.GetJenkinsMasterPod()
+	if err != nil && apierrors.IsNotFound(err) {
+		r.logger.V(log.VWarn).Info("Skipping backup before deletion, Jenkins master pod doesn't exist")
+		return nil
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	if r.IsJenkinsTerminating(*pod) || pod.Status.Phase != corev1.PodRunning {
+		r.logger.V(log.VWarn).Info("Skipping backup before deletion, Jenkins master pod isn't running")
+		return nil
+	}
+
+	if jenkins.Status.LastBackup == jenkins.Status.PendingBackup {
+		jenkins.Status.PendingBackup++
+	}
+	r.logger.Info(fmt.Sprintf("Making backup '%d' before deletion", jenkins.Status.PendingBackup))
+	if err = backupAndRestore.Backup(false); err != nil {
+		return stackerr.Wrap(err, "backup before deletion failed, disable spec.backup.makeBackupBeforeDeletion to skip it")
+	}
+	return nil
+}
+
+// removeLabelForWatchesResources removes labels set by addLabelForWatchesResources
+func (r *JenkinsBaseConfigurationReconciler) removeLabelForWatchesResources(customization v1alpha2.Customization) error {
+	if len(customization.Secret.Name) > 0 {
+		if err := r.removeLabelForWatchedResource(&corev1.Secret{}, customization.Secret.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, configMapRef := range customization.Configurations {
+		if err := r.removeLabelForWatchedResource(&corev1.ConfigMap{}, configMapRef.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) removeLabelForWatchedResource(object client.Object, name string) error {
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: r.Configuration.Jenkins.Namespace}, object)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	labels := object.GetLabels()
+	if labels[constants.LabelJenkinsCRKey] != r.Configuration.Jenkins.Name {
+		return nil // watched for another Jenkins CR
+	}
+	for key, value := range resources.BuildLabelsForWatchedResources(*r.Configuration.Jenkins) {
+		if labels[key] == value {
+			delete(labels, key)
+		}
+	}
+	object.SetLabels(labels)
+
+	return stackerr.WithStack(r.Client.Update(context.TODO(), object))
+}
@@ -0,0 +1,78 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFinalize(t *testing.T) {
+	log.SetupLogger(true)
+	namespace := "default"
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: namespace,
+		},
+		Spec: v1alpha2.JenkinsSpec{
+			Backup: v1alpha2.Backup{MakeBackupBeforeDeletion: true},
+			ConfigurationAsCode: v1alpha2.ConfigurationAsCode{
+				Customization: v1alpha2.Customization{
+					Secret:         v1alpha2.SecretRef{Name: "casc-secret"},
+					Configurations: []v1alpha2.ConfigMapRef{{Name: "casc"}, {Name: "shared"}, {Name: "missing"}},
+				},
+			},
+		},
+	}
+	watchedLabels := func(jenkinsName string) map[string]string {
+		labels := resources.BuildLabelsForWatchedResources(v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: jenkinsName}})
+		labels["team"] = "ci"
+		return labels
+	}
+	metaObject := resources.NewResourceObjectMeta(jenkins)
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "casc-secret", Namespace: namespace, Labels: watchedLabels(jenkins.Name)}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "casc", Namespace: namespace, Labels: watchedLabels(jenkins.Name)}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: namespace, Labels: watchedLabels("another")}},
+		resources.NewRoleBinding(getExtraRoleBindingName(metaObject.Name, rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"}),
+			namespace, metaObject.Name, rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"}),
+		resources.NewRoleBinding(metaObject.Name, namespace, metaObject.Name, rbacv1.RoleRef{Kind: "Role", Name: metaObject.Name}),
+	).Build()
+	reconciler := New(configuration.Configuration{
+		Client:  fakeClient,
+		Jenkins: jenkins,
+		Scheme:  scheme.Scheme,
+	}, client.JenkinsAPIConnectionSettings{})
+
+	err := reconciler.Finalize()
+
+	assert.NoError(t, err)
+	getLabels := func(object k8sclient.Object, name string) map[string]string {
+		err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, object)
+		assert.NoError(t, err)
+		return object.GetLabels()
+	}
+	assert.Equal(t, map[string]string{"team": "ci"}, getLabels(&corev1.Secret{}, "casc-secret"))
+	assert.Equal(t, map[string]string{"team": "ci"}, getLabels(&corev1.ConfigMap{}, "casc"))
+	assert.Equal(t, watchedLabels("another"), getLabels(&corev1.ConfigMap{}, "shared"))
+	roleBindings := &rbacv1.RoleBindingList{}
+	err = fakeClient.List(context.TODO(), roleBindings, k8sclient.InNamespace(namespace))
+	assert.NoError(t, err)
+	if assert.Len(t, roleBindings.Items, 1) {
+		assert.Equal(t, metaObject.Name, roleBindings.Items[0].Name)
+	}
+}
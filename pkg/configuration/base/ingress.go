@@ -0,0 +1,79 @@
+package base
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	stackerr "github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ensureIngress creates, updates or deletes the Ingress according to spec.ingress
+func (r *JenkinsBaseConfigurationReconciler) ensureIngress(meta metav1.ObjectMeta) error {
+	ingress := r.Configuration.Jenkins.Spec.Ingress
+	if ingress == nil || ingress.Type != v1alpha2.IngressIngressType {
+		obj := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.GetJenkinsIngressName(r.Configuration.Jenkins),
+			Namespace: r.Configuration.Jenkins.Namespace,
+		}}
+		return r.deleteDisabledObject(obj)
+	}
+
+	expected := resources.NewJenkinsIngress(meta, r.Configuration.Jenkins)
+	setObjectDeleted(expected, false)
+	actual := &networkingv1.Ingress{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, actual)
+	if err != nil && apierrors.IsNotFound(err) {
+		return stackerr.WithStack(r.CreateResource(expected))
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	if r.isObjectUpToDate(expected, actual, expected.Spec, actual.Spec) &&
+		equality.Semantic.DeepEqual(expected.Annotations, actual.Annotations) {
+		return nil
+	}
+
+	actual.Labels = expected.Labels // make sure that user won't break ingress by hand
+	actual.Annotations = expected.Annotations
+	actual.Spec = expected.Spec
+	return stackerr.WithStack(r.UpdateResource(actual))
+}
+
+// ensureHTTPRoute creates, updates or deletes the Gateway API HTTPRoute according to spec.ingress
+func (r *JenkinsBaseConfigurationReconciler) ensureHTTPRoute(meta metav1.ObjectMeta) error {
+	ingress := r.Configuration.Jenkins.Spec.Ingress
+	if ingress == nil || ingress.Type != v1alpha2.HTTPRouteIngressType {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(resources.HTTPRouteGVK)
+		obj.SetName(resources.GetJenkinsIngressName(r.Configuration.Jenkins))
+		obj.SetNamespace(r.Configuration.Jenkins.Namespace)
+		return r.deleteDisabledObject(obj)
+	}
+
+	expected := resources.NewJenkinsHTTPRoute(meta, r.Configuration.Jenkins)
+	setObjectDeleted(expected, false)
+	actual := &unstructured.Unstructured{}
+	actual.SetGroupVersionKind(resources.HTTPRouteGVK)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: expected.GetName(), Namespace: expected.GetNamespace()}, actual)
+	if err != nil && apierrors.IsNotFound(err) {
+		return stackerr.WithStack(r.CreateResource(expected))
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	if r.isObjectUpToDate(expected, actual, expected.Object["spec"], actual.Object["spec"]) &&
+		equality.Semantic.DeepEqual(expected.GetAnnotations(), actual.GetAnnotations()) {
+		return nil
+	}
+
+	actual.SetLabels(expected.GetLabels()) // make sure that user won't break HTTPRoute by hand
+	actual.SetAnnotations(expected.GetAnnotations())
+	actual.Object["spec"] = expected.Object["spec"]
+	return stackerr.WithStack(r.UpdateResource(actual))
+}
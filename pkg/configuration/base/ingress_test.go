@@ -0,0 +1,113 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureIngress(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+		Spec: v1alpha2.JenkinsSpec{
+			Ingress: &v1alpha2.Ingress{Type: v1alpha2.IngressIngressType, Host: "jenkins.example.com", Path: "/"},
+		},
+	}
+	jenkins.SetDefaults()
+	fakeClient := fake.NewClientBuilder().Build()
+	countingClient := &deleteCountingClient{Client: fakeClient}
+	reconciler := New(configuration.Configuration{
+		Client:  countingClient,
+		Jenkins: jenkins,
+		Scheme:  scheme.Scheme,
+	}, client.JenkinsAPIConnectionSettings{})
+	metaObject := resources.NewResourceObjectMeta(jenkins)
+	name := types.NamespacedName{Name: resources.GetJenkinsIngressName(jenkins), Namespace: defaultNamespace}
+	getHTTPRoute := func() (*unstructured.Unstructured, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(resources.HTTPRouteGVK)
+		err := fakeClient.Get(context.TODO(), name, obj)
+		return obj, err
+	}
+
+	t.Run("create Ingress", func(t *testing.T) {
+		require.NoError(t, reconciler.ensureIngress(metaObject))
+		require.NoError(t, reconciler.ensureHTTPRoute(metaObject))
+
+		ingress := &networkingv1.Ingress{}
+		require.NoError(t, fakeClient.Get(context.TODO(), name, ingress))
+		assert.Equal(t, "jenkins.example.com", ingress.Spec.Rules[0].Host)
+		assert.Len(t, ingress.OwnerReferences, 1)
+		_, err := getHTTPRoute()
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("don't update unchanged Ingress", func(t *testing.T) {
+		ingress := &networkingv1.Ingress{}
+		require.NoError(t, fakeClient.Get(context.TODO(), name, ingress))
+
+		require.NoError(t, reconciler.ensureIngress(metaObject))
+		require.NoError(t, reconciler.ensureHTTPRoute(metaObject))
+
+		actual := &networkingv1.Ingress{}
+		require.NoError(t, fakeClient.Get(context.TODO(), name, actual))
+		assert.Equal(t, ingress.ResourceVersion, actual.ResourceVersion)
+		assert.Equal(t, 1, countingClient.deletes)
+	})
+	t.Run("update Ingress", func(t *testing.T) {
+		jenkins.Spec.Ingress.TLSSecretName = "jenkins-tls"
+		jenkins.Spec.Ingress.Annotations = map[string]string{"cert-manager.io/cluster-issuer": "letsencrypt"}
+
+		require.NoError(t, reconciler.ensureIngress(metaObject))
+
+		ingress := &networkingv1.Ingress{}
+		require.NoError(t, fakeClient.Get(context.TODO(), name, ingress))
+		assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"jenkins.example.com"}, SecretName: "jenkins-tls"}}, ingress.Spec.TLS)
+		assert.Equal(t, "letsencrypt", ingress.Annotations["cert-manager.io/cluster-issuer"])
+	})
+	t.Run("switch to HTTPRoute", func(t *testing.T) {
+		jenkins.Spec.Ingress.Type = v1alpha2.HTTPRouteIngressType
+		jenkins.Spec.Ingress.Gateway = &v1alpha2.GatewayReference{Name: "gateway"}
+
+		require.NoError(t, reconciler.ensureIngress(metaObject))
+		require.NoError(t, reconciler.ensureHTTPRoute(metaObject))
+
+		err := fakeClient.Get(context.TODO(), name, &networkingv1.Ingress{})
+		assert.True(t, apierrors.IsNotFound(err))
+		httpRoute, err := getHTTPRoute()
+		require.NoError(t, err)
+		hostnames, _, _ := unstructured.NestedStringSlice(httpRoute.Object, "spec", "hostnames")
+		assert.Equal(t, []string{"jenkins.example.com"}, hostnames)
+		assert.Len(t, httpRoute.GetOwnerReferences(), 1)
+	})
+	t.Run("delete", func(t *testing.T) {
+		jenkins.Spec.Ingress = nil
+
+		require.NoError(t, reconciler.ensureIngress(metaObject))
+		require.NoError(t, reconciler.ensureHTTPRoute(metaObject))
+
+		_, err := getHTTPRoute()
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.Equal(t, 3, countingClient.deletes)
+	})
+	t.Run("delete only once", func(t *testing.T) {
+		require.NoError(t, reconciler.ensureIngress(metaObject))
+		require.NoError(t, reconciler.ensureHTTPRoute(metaObject))
+
+		assert.Equal(t, 3, countingClient.deletes)
+	})
+}
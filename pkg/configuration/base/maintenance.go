@@ -0,0 +1,134 @@
+package base
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	stackerr "github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const timeOfDayLayout = "15:04"
+
+type timeWindow struct {
+	days     map[time.Weekday]bool
+	start    time.Time
+	end      time.Time
+	location *time.Location
+}
+
+func newTimeWindow(window v1alpha2.TimeWindow) (*timeWindow, error) {
+	start, err := time.Parse(timeOfDayLayout, window.Start)
+	if err != nil {
+		return nil, stackerr.Wrapf(err, "couldn't parse start '%s'", window.Start)
+	}
+	end, err := time.Parse(timeOfDayLayout, window.End)
+	if err != nil {
+		return nil, stackerr.Wrapf(err, "couldn't parse end '%s'", window.End)
+	}
+	location, err := time.LoadLocation(window.TimeZone)
+	if err != nil {
+		return nil, stackerr.Wrapf(err, "couldn't load time zone '%s'", window.TimeZone)
+	}
+
+	days := map[time.Weekday]bool{}
+	for _, day := range window.Days {
+		weekday, found := parseWeekday(day)
+		if !found {
+			return nil, stackerr.Errorf("unrecognized day '%s'", day)
+		}
+		days[weekday] = true
+	}
+
+	return &timeWindow{days: days, start: start, end: end, location: location}, nil
+}
+
+func parseWeekday(day v1alpha2.Weekday) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if weekday.String() == string(day) {
+			return weekday, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// next returns the start and the end of the current or the next occurrence of the window
+func (w *timeWindow) next(now time.Time) (time.Time, time.Time) {
+	local := now.In(w.location)
+	// the window which has started yesterday can end today
+	for offset := -1; ; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, w.location)
+		if len(w.days) > 0 && !w.days[day.Weekday()] {
+			continue
+		}
+
+		start := time.Date(day.Year(), day.Month(), day.Day(), w.start.Hour(), w.start.Minute(), 0, 0, w.location)
+		end := time.Date(day.Year(), day.Month(), day.Day(), w.end.Hour(), w.end.Minute(), 0, 0, w.location)
+		if !end.After(start) {
+			end = time.Date(day.Year(), day.Month(), day.Day()+1, w.end.Hour(), w.end.Minute(), 0, 0, w.location)
+		}
+		if end.After(now) {
+			return start, end
+		}
+	}
+}
+
+// nextMaintenanceWindow returns the current or the next maintenance window, the one which starts first
+func nextMaintenanceWindow(windows []v1alpha2.TimeWindow, now time.Time) (*v1alpha2.MaintenanceWindowStatus, error) {
+	var next *v1alpha2.MaintenanceWindowStatus
+	for _, window := range windows {
+		w, err := newTimeWindow(window)
+		if err != nil {
+			return nil, err
+		}
+		start, end := w.next(now)
+		if next == nil || start.Before(next.Start.Time) {
+			next = &v1alpha2.MaintenanceWindowStatus{Start: metav1.NewTime(start.UTC()), End: metav1.NewTime(end.UTC())}
+		}
+	}
+
+	return next, nil
+}
+
+// isOutsideMaintenanceWindow tells if disruptive actions have to wait for the maintenance window
+func (r *JenkinsBaseConfigurationReconciler) isOutsideMaintenanceWindow(now time.Time) (bool, string, error) {
+	jenkins := r.Configuration.Jenkins
+	if len(jenkins.Spec.MaintenanceWindows) == 0 || jenkins.Annotations[constants.MaintenanceWindowOverrideAnnotation] == "true" {
+		return false, "", nil
+	}
+
+	next, err := nextMaintenanceWindow(jenkins.Spec.MaintenanceWindows, now)
+	if err != nil {
+		return false, "", err
+	}
+	if !next.Start.After(now) {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf("Waiting for the maintenance window starting at %s", next.Start.Format(time.RFC3339)), nil
+}
+
+// updateMaintenanceWindowStatus sets the current or the next maintenance window in the status, it's persisted
+// by the controller at the end of the reconcile loop
+func (r *JenkinsBaseConfigurationReconciler) updateMaintenanceWindowStatus() error {
+	jenkins := r.Configuration.Jenkins
+	var next *v1alpha2.MaintenanceWindowStatus
+	if len(jenkins.Spec.MaintenanceWindows) > 0 {
+		var err error
+		next, err = nextMaintenanceWindow(jenkins.Spec.MaintenanceWindows, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	current := jenkins.Status.NextMaintenanceWindow
+	if next != nil && current != nil && next.Start.Equal(&current.Start) && next.End.Equal(&current.End) {
+		return nil
+	}
+
+	jenkins.Status.NextMaintenanceWindow = next
+	return nil
+}
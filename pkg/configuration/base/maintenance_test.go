@@ -0,0 +1,145 @@
+package base
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNextMaintenanceWindow(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	// Saturday
+	now := time.Date(2021, 3, 6, 1, 30, 0, 0, location)
+
+	tests := []struct {
+		name    string
+		windows []v1alpha2.TimeWindow
+		start   time.Time
+		end     time.Time
+	}{
+		{
+			name:    "every day, open",
+			windows: []v1alpha2.TimeWindow{{Start: "01:00", End: "03:00", TimeZone: "Europe/Warsaw"}},
+			start:   time.Date(2021, 3, 6, 1, 0, 0, 0, location),
+			end:     time.Date(2021, 3, 6, 3, 0, 0, 0, location),
+		},
+		{
+			name:    "every day, started yesterday",
+			windows: []v1alpha2.TimeWindow{{Start: "22:00", End: "02:00", TimeZone: "Europe/Warsaw"}},
+			start:   time.Date(2021, 3, 5, 22, 0, 0, 0, location),
+			end:     time.Date(2021, 3, 6, 2, 0, 0, 0, location),
+		},
+		{
+			name:    "every day, ended",
+			windows: []v1alpha2.TimeWindow{{Start: "00:00", End: "01:00", TimeZone: "Europe/Warsaw"}},
+			start:   time.Date(2021, 3, 7, 0, 0, 0, 0, location),
+			end:     time.Date(2021, 3, 7, 1, 0, 0, 0, location),
+		},
+		{
+			name:    "weekdays",
+			windows: []v1alpha2.TimeWindow{{Days: []v1alpha2.Weekday{"Monday", "Wednesday"}, Start: "01:00", End: "03:00", TimeZone: "Europe/Warsaw"}},
+			start:   time.Date(2021, 3, 8, 1, 0, 0, 0, location),
+			end:     time.Date(2021, 3, 8, 3, 0, 0, 0, location),
+		},
+		{
+			name:    "time zone",
+			windows: []v1alpha2.TimeWindow{{Start: "01:00", End: "03:00", TimeZone: "UTC"}},
+			start:   time.Date(2021, 3, 6, 1, 0, 0, 0, time.UTC),
+			end:     time.Date(2021, 3, 6, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "the first of many",
+			windows: []v1alpha2.TimeWindow{
+				{Days: []v1alpha2.Weekday{"Sunday"}, Start: "10:00", End: "12:00", TimeZone: "Europe/Warsaw"},
+				{Days: []v1alpha2.Weekday{"Saturday"}, Start: "20:00", End: "23:00", TimeZone: "Europe/Warsaw"},
+			},
+			start: time.Date(2021, 3, 6, 20, 0, 0, 0, location),
+			end:   time.Date(2021, 3, 6, 23, 0, 0, 0, location),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nextMaintenanceWindow(tt.windows, now)
+
+			require.NoError(t, err)
+			assert.True(t, tt.start.Equal(got.Start.Time), "start %s", got.Start)
+			assert.True(t, tt.end.Equal(got.End.Time), "end %s", got.End)
+		})
+	}
+}
+
+func TestIsOutsideMaintenanceWindow(t *testing.T) {
+	now := time.Date(2021, 3, 6, 12, 0, 0, 0, time.UTC)
+	newReconciler := func(annotations map[string]string, windows ...v1alpha2.TimeWindow) *JenkinsBaseConfigurationReconciler {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec:       v1alpha2.JenkinsSpec{MaintenanceWindows: windows},
+		}
+		return New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("no maintenance windows", func(t *testing.T) {
+		outside, _, err := newReconciler(nil).isOutsideMaintenanceWindow(now)
+
+		require.NoError(t, err)
+		assert.False(t, outside)
+	})
+	t.Run("inside", func(t *testing.T) {
+		outside, _, err := newReconciler(nil, v1alpha2.TimeWindow{Start: "11:00", End: "13:00"}).isOutsideMaintenanceWindow(now)
+
+		require.NoError(t, err)
+		assert.False(t, outside)
+	})
+	t.Run("outside", func(t *testing.T) {
+		outside, message, err := newReconciler(nil, v1alpha2.TimeWindow{Start: "22:00", End: "02:00"}).isOutsideMaintenanceWindow(now)
+
+		require.NoError(t, err)
+		assert.True(t, outside)
+		assert.Equal(t, "Waiting for the maintenance window starting at 2021-03-06T22:00:00Z", message)
+	})
+	t.Run("override", func(t *testing.T) {
+		annotations := map[string]string{constants.MaintenanceWindowOverrideAnnotation: "true"}
+
+		outside, _, err := newReconciler(annotations, v1alpha2.TimeWindow{Start: "22:00", End: "02:00"}).isOutsideMaintenanceWindow(now)
+
+		require.NoError(t, err)
+		assert.False(t, outside)
+	})
+}
+
+func TestUpdateMaintenanceWindowStatus(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+		Spec:       v1alpha2.JenkinsSpec{MaintenanceWindows: []v1alpha2.TimeWindow{{Start: "22:00", End: "02:00", TimeZone: "UTC"}}},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(jenkins.DeepCopy()).Build()
+	reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+
+	require.NoError(t, reconciler.updateMaintenanceWindowStatus())
+
+	assert.NotNil(t, jenkins.Status.NextMaintenanceWindow)
+	// the status is persisted by the controller at the end of the reconcile loop
+	persisted := &v1alpha2.Jenkins{}
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, persisted))
+	assert.Nil(t, persisted.Status.NextMaintenanceWindow)
+
+	jenkins.Spec.MaintenanceWindows = nil
+
+	require.NoError(t, reconciler.updateMaintenanceWindowStatus())
+
+	assert.Nil(t, jenkins.Status.NextMaintenanceWindow)
+}
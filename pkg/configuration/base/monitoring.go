@@ -0,0 +1,41 @@
+package base
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ensureMonitoring creates, updates or deletes the ServiceMonitor and the PrometheusRule according to spec.monitoring
+func (r *JenkinsBaseConfigurationReconciler) ensureMonitoring(meta metav1.ObjectMeta) error {
+	monitoring := r.Configuration.Jenkins.Spec.Monitoring
+	if monitoring == nil {
+		if err := r.deleteMonitoringObject(resources.ServiceMonitorGVK); err != nil {
+			return err
+		}
+		return r.deleteMonitoringObject(resources.PrometheusRuleGVK)
+	}
+
+	if err := r.createOrUpdateMonitoringObject(resources.NewServiceMonitor(meta, r.Configuration.Jenkins)); err != nil {
+		return err
+	}
+	if monitoring.DisablePrometheusRule {
+		return r.deleteMonitoringObject(resources.PrometheusRuleGVK)
+	}
+	return r.createOrUpdateMonitoringObject(resources.NewPrometheusRule(meta, r.Configuration.Jenkins))
+}
+
+func (r *JenkinsBaseConfigurationReconciler) createOrUpdateMonitoringObject(expected *unstructured.Unstructured) error {
+	return r.createOrUpdateUnstructured(expected) // make sure that user won't break monitoring by hand
+}
+
+func (r *JenkinsBaseConfigurationReconciler) deleteMonitoringObject(gvk schema.GroupVersionKind) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(resources.GetMonitoringName(r.Configuration.Jenkins))
+	obj.SetNamespace(r.Configuration.Jenkins.Namespace)
+
+	return r.deleteDisabledObject(obj)
+}
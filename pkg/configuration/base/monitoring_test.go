@@ -0,0 +1,114 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureMonitoring(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	assert.NoError(t, err)
+
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+		},
+		Spec: v1alpha2.JenkinsSpec{
+			Monitoring: &v1alpha2.Monitoring{
+				Labels: map[string]string{"release": "prometheus"},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().Build()
+	countingClient := &deleteCountingClient{Client: fakeClient}
+	reconciler := New(configuration.Configuration{
+		Client:  countingClient,
+		Jenkins: jenkins,
+		Scheme:  scheme.Scheme,
+	}, client.JenkinsAPIConnectionSettings{})
+	metaObject := resources.NewResourceObjectMeta(jenkins)
+
+	get := func(c k8sclient.Client, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		err := c.Get(context.TODO(), types.NamespacedName{Name: resources.GetMonitoringName(jenkins), Namespace: jenkins.Namespace}, obj)
+		return obj, err
+	}
+
+	t.Run("create", func(t *testing.T) {
+		err := reconciler.ensureMonitoring(metaObject)
+		assert.NoError(t, err)
+
+		serviceMonitor, err := get(fakeClient, resources.ServiceMonitorGVK)
+		assert.NoError(t, err)
+		assert.Equal(t, "prometheus", serviceMonitor.GetLabels()["release"])
+		assert.Len(t, serviceMonitor.GetOwnerReferences(), 1)
+		_, err = get(fakeClient, resources.PrometheusRuleGVK)
+		assert.NoError(t, err)
+	})
+	t.Run("don't update unchanged", func(t *testing.T) {
+		serviceMonitor, err := get(fakeClient, resources.ServiceMonitorGVK)
+		assert.NoError(t, err)
+
+		err = reconciler.ensureMonitoring(metaObject)
+		assert.NoError(t, err)
+
+		actual, err := get(fakeClient, resources.ServiceMonitorGVK)
+		assert.NoError(t, err)
+		assert.Equal(t, serviceMonitor.GetResourceVersion(), actual.GetResourceVersion())
+	})
+	t.Run("update", func(t *testing.T) {
+		jenkins.Spec.Monitoring.ScrapeInterval = "1m"
+
+		err := reconciler.ensureMonitoring(metaObject)
+		assert.NoError(t, err)
+
+		serviceMonitor, err := get(fakeClient, resources.ServiceMonitorGVK)
+		assert.NoError(t, err)
+		endpoints, _, _ := unstructured.NestedSlice(serviceMonitor.Object, "spec", "endpoints")
+		assert.Equal(t, "1m", endpoints[0].(map[string]interface{})["interval"])
+	})
+	t.Run("disable prometheus rule", func(t *testing.T) {
+		jenkins.Spec.Monitoring.DisablePrometheusRule = true
+
+		err := reconciler.ensureMonitoring(metaObject)
+		assert.NoError(t, err)
+
+		_, err = get(fakeClient, resources.ServiceMonitorGVK)
+		assert.NoError(t, err)
+		_, err = get(fakeClient, resources.PrometheusRuleGVK)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("monitoring removed", func(t *testing.T) {
+		jenkins.Spec.Monitoring = nil
+
+		err := reconciler.ensureMonitoring(metaObject)
+		assert.NoError(t, err)
+
+		_, err = get(fakeClient, resources.ServiceMonitorGVK)
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.Equal(t, 2, countingClient.deletes)
+	})
+	t.Run("delete only once", func(t *testing.T) {
+		err := reconciler.ensureMonitoring(metaObject)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 2, countingClient.deletes)
+	})
+}
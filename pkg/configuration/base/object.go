@@ -0,0 +1,98 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	stackerr "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// deletedObjects remembers the objects of disabled features deleted by the operator, so the delete request isn't
+// sent in every reconcile loop
+var deletedObjects = struct {
+	sync.Mutex
+	keys map[string]bool
+}{keys: map[string]bool{}}
+
+func deletedObjectKey(obj k8sclient.Object) string {
+	return fmt.Sprintf("%T/%s/%s/%s", obj, obj.GetObjectKind().GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+}
+
+func setObjectDeleted(obj k8sclient.Object, deleted bool) {
+	deletedObjects.Lock()
+	defer deletedObjects.Unlock()
+
+	if deleted {
+		deletedObjects.keys[deletedObjectKey(obj)] = true
+	} else {
+		delete(deletedObjects.keys, deletedObjectKey(obj))
+	}
+}
+
+func isObjectDeleted(obj k8sclient.Object) bool {
+	deletedObjects.Lock()
+	defer deletedObjects.Unlock()
+
+	return deletedObjects.keys[deletedObjectKey(obj)]
+}
+
+// deleteDisabledObject deletes the object of the disabled feature, the delete request is sent only once after
+// the operator start or after the object has been created or updated by the operator
+func (r *JenkinsBaseConfigurationReconciler) deleteDisabledObject(obj k8sclient.Object) error {
+	if isObjectDeleted(obj) {
+		return nil
+	}
+
+	err := r.Client.Delete(context.TODO(), obj)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return stackerr.WithStack(err)
+	}
+	setObjectDeleted(obj, true)
+	return nil
+}
+
+// isObjectUpToDate tells if the object has the expected labels, annotations (if any), spec and is controlled
+// by the Jenkins CR, fields defaulted by the API server in the actual spec are ignored
+func (r *JenkinsBaseConfigurationReconciler) isObjectUpToDate(expected, actual metav1.Object, expectedSpec, actualSpec interface{}) bool {
+	if !equality.Semantic.DeepEqual(expected.GetLabels(), actual.GetLabels()) {
+		return false
+	}
+	if len(expected.GetAnnotations()) > 0 && !equality.Semantic.DeepEqual(expected.GetAnnotations(), actual.GetAnnotations()) {
+		return false
+	}
+	if !metav1.IsControlledBy(actual, r.Configuration.Jenkins) {
+		return false
+	}
+	return equality.Semantic.DeepDerivative(expectedSpec, actualSpec)
+}
+
+// createOrUpdateUnstructured creates the object or restores its labels, annotations and spec changed by hand
+func (r *JenkinsBaseConfigurationReconciler) createOrUpdateUnstructured(expected *unstructured.Unstructured) error {
+	setObjectDeleted(expected, false)
+
+	actual := &unstructured.Unstructured{}
+	actual.SetGroupVersionKind(expected.GroupVersionKind())
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: expected.GetName(), Namespace: expected.GetNamespace()}, actual)
+	if err != nil && apierrors.IsNotFound(err) {
+		return stackerr.WithStack(r.CreateResource(expected))
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	if r.isObjectUpToDate(expected, actual, expected.Object["spec"], actual.Object["spec"]) {
+		return nil
+	}
+
+	actual.SetLabels(expected.GetLabels())
+	if len(expected.GetAnnotations()) > 0 {
+		actual.SetAnnotations(expected.GetAnnotations())
+	}
+	actual.Object["spec"] = expected.Object["spec"]
+	return stackerr.WithStack(r.UpdateResource(actual))
+}
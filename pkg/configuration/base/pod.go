@@ -3,10 +3,14 @@ package base
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 	"github.com/jenkinsci/kubernetes-operator/version"
@@ -15,6 +19,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -39,6 +44,24 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 		verbose = append(verbose, "User or password have changed, recreating pod")
 	}
 
+	customResourceReplaced := (r.Configuration.Jenkins.Status.BaseConfigurationCompletedTime == nil ||
+		r.Configuration.Jenkins.Status.UserConfigurationCompletedTime == nil) &&
+		r.Configuration.Jenkins.Status.UserAndPasswordHash == ""
+
+	if customResourceReplaced {
+		messages = append(messages, "Jenkins CR has been replaced")
+		verbose = append(verbose, "Jenkins CR has been replaced")
+	}
+
+	return reason.NewPodRestart(reason.OperatorSource, messages, verbose...)
+}
+
+// checkForDeferrablePodRecreation checks the operator upgrade, the restore and the Jenkins master pod changes
+// against spec.master, the restart caused by them can be deferred by spec.maintenanceWindows and spec.restartPolicy
+func (r *JenkinsBaseConfigurationReconciler) checkForDeferrablePodRecreation(currentJenkinsMasterPod corev1.Pod) (reason.Reason, error) {
+	var messages []string
+	var verbose []string
+
 	if r.Configuration.Jenkins.Spec.Restore.RecoveryOnce != 0 && r.Configuration.Jenkins.Status.RestoredBackup != 0 {
 		messages = append(messages, "spec.restore.recoveryOnce is set")
 		verbose = append(verbose, "spec.restore.recoveryOnce is set, recreating pod")
@@ -50,6 +73,23 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 			r.Configuration.Jenkins.Status.OperatorVersion, version.Version))
 	}
 
+	// the PodDisruptionBudget blocks the eviction, the operator moves the pod from the drained node
+	if podDisruptionBudget := r.Configuration.Jenkins.Spec.Master.PodDisruptionBudget; podDisruptionBudget != nil && podDisruptionBudget.Enabled {
+		cordoned, err := r.isJenkinsMasterPodNodeCordoned(currentJenkinsMasterPod)
+		if err != nil {
+			return nil, err
+		}
+		if cordoned {
+			messages = append(messages, "Jenkins pod node is cordoned")
+			verbose = append(verbose, fmt.Sprintf("Jenkins pod node '%s' is cordoned", currentJenkinsMasterPod.Spec.NodeName))
+		}
+	}
+
+	// the Deployment or StatefulSet replaces the pod when the pod template changes
+	if isJenkinsMasterPodManagedByController(r.Configuration.Jenkins) {
+		return reason.NewPodRestart(reason.OperatorSource, messages, verbose...), nil
+	}
+
 	//FIXME too hacky
 	/*var jenkinsSecurityContext *corev1.PodSecurityContext
 	if r.Configuration.Jenkins.Spec.Master.SecurityContext == nil {
@@ -99,21 +139,24 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 			len(currentJenkinsMasterPod.Spec.Containers), len(r.Configuration.Jenkins.Spec.Master.Containers)))
 	}
 
+	if !reflect.DeepEqual(r.Configuration.Jenkins.Spec.Master.Affinity, currentJenkinsMasterPod.Spec.Affinity) {
+		messages = append(messages, "Jenkins pod affinity has changed")
+		verbose = append(verbose, fmt.Sprintf("Jenkins pod affinity has changed, actual '%+v' required '%+v'",
+			currentJenkinsMasterPod.Spec.Affinity, r.Configuration.Jenkins.Spec.Master.Affinity))
+	}
+
+	if !compareTopologySpreadConstraints(r.Configuration.Jenkins.Spec.Master.TopologySpreadConstraints, currentJenkinsMasterPod.Spec.TopologySpreadConstraints) {
+		messages = append(messages, "Jenkins pod topology spread constraints have changed")
+		verbose = append(verbose, fmt.Sprintf("Jenkins pod topology spread constraints have changed, actual '%+v' required '%+v'",
+			currentJenkinsMasterPod.Spec.TopologySpreadConstraints, r.Configuration.Jenkins.Spec.Master.TopologySpreadConstraints))
+	}
+
 	if r.Configuration.Jenkins.Spec.Master.PriorityClassName != currentJenkinsMasterPod.Spec.PriorityClassName {
 		messages = append(messages, "Jenkins priorityClassName has changed")
 		verbose = append(verbose, fmt.Sprintf("Jenkins priorityClassName has changed, actual '%+v' required '%+v'",
 			currentJenkinsMasterPod.Spec.PriorityClassName, r.Configuration.Jenkins.Spec.Master.PriorityClassName))
 	}
 
-	customResourceReplaced := (r.Configuration.Jenkins.Status.BaseConfigurationCompletedTime == nil ||
-		r.Configuration.Jenkins.Status.UserConfigurationCompletedTime == nil) &&
-		r.Configuration.Jenkins.Status.UserAndPasswordHash == ""
-
-	if customResourceReplaced {
-		messages = append(messages, "Jenkins CR has been replaced")
-		verbose = append(verbose, "Jenkins CR has been replaced")
-	}
-
 	for _, actualContainer := range currentJenkinsMasterPod.Spec.Containers {
 		if actualContainer.Name == resources.JenkinsMasterContainerName {
 			containerMessages, verboseMessages := r.compareContainers(resources.NewJenkinsMasterContainer(r.Configuration.Jenkins), actualContainer)
@@ -142,7 +185,7 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 		verbose = append(verbose, verboseMessages...)
 	}
 
-	return reason.NewPodRestart(reason.OperatorSource, messages, verbose...)
+	return reason.NewPodRestart(reason.OperatorSource, messages, verbose...), nil
 }
 
 func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.ObjectMeta) (reconcile.Result, error) {
@@ -167,15 +210,7 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 			return reconcile.Result{}, stackerr.WithStack(err)
 		}
 
-		now := metav1.Now()
-		r.Configuration.Jenkins.Status = v1alpha2.JenkinsStatus{
-			OperatorVersion:     version.Version,
-			ProvisionStartTime:  &now,
-			LastBackup:          r.Configuration.Jenkins.Status.LastBackup,
-			PendingBackup:       r.Configuration.Jenkins.Status.LastBackup,
-			UserAndPasswordHash: userAndPasswordHash,
-		}
-		return reconcile.Result{Requeue: true}, r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins)
+		return reconcile.Result{Requeue: true}, r.resetStatus(metav1.Now(), userAndPasswordHash)
 	} else if err != nil && !apierrors.IsNotFound(err) {
 		return reconcile.Result{}, stackerr.WithStack(err)
 	}
@@ -184,7 +219,29 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	if r.IsJenkinsTerminating(*currentJenkinsMasterPod) && r.Configuration.Jenkins.Status.UserConfigurationCompletedTime != nil {
+	return r.checkJenkinsMasterPod(*currentJenkinsMasterPod, userAndPasswordHash)
+}
+
+// resetStatus resets the Jenkins CR status after the new Jenkins master pod has been created, so all configuration
+// phases run against it
+func (r *JenkinsBaseConfigurationReconciler) resetStatus(provisionStartTime metav1.Time, userAndPasswordHash string) error {
+	r.Configuration.Jenkins.Status = v1alpha2.JenkinsStatus{
+		OperatorVersion:     version.Version,
+		ProvisionStartTime:  &provisionStartTime,
+		LastBackup:          r.Configuration.Jenkins.Status.LastBackup,
+		PendingBackup:       r.Configuration.Jenkins.Status.LastBackup,
+		UserAndPasswordHash: userAndPasswordHash,
+	}
+	return configuration.UpdateJenkinsStatus(r.Client, r.Configuration.Jenkins)
+}
+
+// checkJenkinsMasterPod makes a backup when the Jenkins master pod is terminating and restarts it when required
+func (r *JenkinsBaseConfigurationReconciler) checkJenkinsMasterPod(currentJenkinsMasterPod corev1.Pod, userAndPasswordHash string) (reconcile.Result, error) {
+	if r.IsJenkinsTerminating(currentJenkinsMasterPod) {
+		metrics.StopRuntimeCollector(r.Configuration.Jenkins.Namespace, r.Configuration.Jenkins.Name)
+	}
+
+	if r.IsJenkinsTerminating(currentJenkinsMasterPod) && r.Configuration.Jenkins.Status.UserConfigurationCompletedTime != nil {
 		backupAndRestore := backuprestore.New(r.Configuration, r.logger)
 		if backupAndRestore.IsBackupTriggerEnabled() {
 			backupAndRestore.StopBackupTrigger()
@@ -194,15 +251,28 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 			if r.Configuration.Jenkins.Status.LastBackup == r.Configuration.Jenkins.Status.PendingBackup {
 				r.Configuration.Jenkins.Status.PendingBackup++
 			}
-			if err = backupAndRestore.Backup(true); err != nil {
+			if err := backupAndRestore.Backup(true); err != nil {
 				return reconcile.Result{}, err
 			}
 		}
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	if !r.IsJenkinsTerminating(*currentJenkinsMasterPod) {
-		restartReason := r.checkForPodRecreation(*currentJenkinsMasterPod, userAndPasswordHash)
+	if !r.IsJenkinsTerminating(currentJenkinsMasterPod) {
+		restartReason := r.checkForPodRecreation(currentJenkinsMasterPod, userAndPasswordHash)
+		if !restartReason.HasMessages() {
+			var err error
+			restartReason, err = r.checkForDeferrablePodRecreation(currentJenkinsMasterPod)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if restartReason.HasMessages() {
+				deferred, err := r.deferRestart(restartReason)
+				if err != nil || deferred {
+					return reconcile.Result{}, err
+				}
+			}
+		}
 		if restartReason.HasMessages() {
 			for _, msg := range restartReason.Verbose() {
 				r.logger.Info(msg)
@@ -214,3 +284,25 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 
 	return reconcile.Result{}, nil
 }
+
+// isJenkinsMasterPodNodeCordoned tells if the node of the Jenkins master pod is unschedulable, e.g. it's being drained
+func (r *JenkinsBaseConfigurationReconciler) isJenkinsMasterPodNodeCordoned(currentJenkinsMasterPod corev1.Pod) (bool, error) {
+	if currentJenkinsMasterPod.Spec.NodeName == "" {
+		return false, nil
+	}
+
+	// the cached client would start a cluster wide node informer, the operator is allowed to get nodes only
+	node := &corev1.Node{}
+	err := r.Configuration.APIReader.Get(context.TODO(), types.NamespacedName{Name: currentJenkinsMasterPod.Spec.NodeName}, node)
+	if err != nil && apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil && apierrors.IsForbidden(err) {
+		// nodes are cluster scoped, the operator limited to namespaces needs a ClusterRole to get them
+		r.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't check if the Jenkins pod node '%s' is cordoned: %s", currentJenkinsMasterPod.Spec.NodeName, err))
+		return false, nil
+	} else if err != nil {
+		return false, stackerr.WithStack(err)
+	}
+
+	return node.Spec.Unschedulable, nil
+}
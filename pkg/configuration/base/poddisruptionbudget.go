@@ -0,0 +1,27 @@
+package base
+
+import (
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// NodeDrainCheckInterval is how often the operator checks if the node of the Jenkins master pod protected by
+// the PodDisruptionBudget is being drained
+const NodeDrainCheckInterval = time.Minute
+
+// ensureJenkinsMasterPodDisruptionBudget creates, updates or deletes the PodDisruptionBudget according to
+// spec.master.podDisruptionBudget
+func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPodDisruptionBudget(meta metav1.ObjectMeta, gvk schema.GroupVersionKind) error {
+	podDisruptionBudget := r.Configuration.Jenkins.Spec.Master.PodDisruptionBudget
+	expected := resources.NewJenkinsMasterPodDisruptionBudget(meta, r.Configuration.Jenkins, gvk)
+	if podDisruptionBudget == nil || !podDisruptionBudget.Enabled {
+		return r.deleteDisabledObject(expected)
+	}
+
+	// make sure that user won't break PodDisruptionBudget by hand
+	return r.createOrUpdateUnstructured(expected)
+}
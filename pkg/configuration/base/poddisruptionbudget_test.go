@@ -0,0 +1,188 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/version"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureJenkinsMasterPodDisruptionBudget(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+	for _, gvk := range []schema.GroupVersionKind{resources.PodDisruptionBudgetV1GVK, resources.PodDisruptionBudgetV1beta1GVK} {
+		t.Run(gvk.Version, func(t *testing.T) {
+			jenkins := &v1alpha2.Jenkins{
+				ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+				Spec: v1alpha2.JenkinsSpec{
+					Master: v1alpha2.JenkinsMaster{PodDisruptionBudget: &v1alpha2.PodDisruptionBudget{Enabled: true}},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().Build()
+			countingClient := &deleteCountingClient{Client: fakeClient}
+			reconciler := New(configuration.Configuration{
+				Client:  countingClient,
+				Jenkins: jenkins,
+				Scheme:  scheme.Scheme,
+			}, client.JenkinsAPIConnectionSettings{})
+			metaObject := resources.NewResourceObjectMeta(jenkins)
+			get := func() (*unstructured.Unstructured, error) {
+				podDisruptionBudget := &unstructured.Unstructured{}
+				podDisruptionBudget.SetGroupVersionKind(gvk)
+				err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: resources.GetJenkinsMasterPodDisruptionBudgetName(jenkins), Namespace: defaultNamespace}, podDisruptionBudget)
+				return podDisruptionBudget, err
+			}
+
+			t.Run("create", func(t *testing.T) {
+				require.NoError(t, reconciler.ensureJenkinsMasterPodDisruptionBudget(metaObject, gvk))
+
+				podDisruptionBudget, err := get()
+				require.NoError(t, err)
+				minAvailable, _, _ := unstructured.NestedInt64(podDisruptionBudget.Object, "spec", "minAvailable")
+				assert.Equal(t, int64(1), minAvailable)
+				matchLabels, _, _ := unstructured.NestedStringMap(podDisruptionBudget.Object, "spec", "selector", "matchLabels")
+				assert.Equal(t, resources.BuildResourceLabels(jenkins), matchLabels)
+				assert.Len(t, podDisruptionBudget.GetOwnerReferences(), 1)
+			})
+			t.Run("don't update unchanged", func(t *testing.T) {
+				podDisruptionBudget, err := get()
+				require.NoError(t, err)
+
+				require.NoError(t, reconciler.ensureJenkinsMasterPodDisruptionBudget(metaObject, gvk))
+
+				actual, err := get()
+				require.NoError(t, err)
+				assert.Equal(t, podDisruptionBudget.GetResourceVersion(), actual.GetResourceVersion())
+			})
+			t.Run("restore changes made by hand", func(t *testing.T) {
+				podDisruptionBudget, err := get()
+				require.NoError(t, err)
+				podDisruptionBudget.Object["spec"] = map[string]interface{}{"maxUnavailable": int64(1)}
+				require.NoError(t, fakeClient.Update(context.TODO(), podDisruptionBudget))
+
+				require.NoError(t, reconciler.ensureJenkinsMasterPodDisruptionBudget(metaObject, gvk))
+
+				podDisruptionBudget, err = get()
+				require.NoError(t, err)
+				minAvailable, _, _ := unstructured.NestedInt64(podDisruptionBudget.Object, "spec", "minAvailable")
+				assert.Equal(t, int64(1), minAvailable)
+				_, found, _ := unstructured.NestedFieldNoCopy(podDisruptionBudget.Object, "spec", "maxUnavailable")
+				assert.False(t, found)
+			})
+			t.Run("delete", func(t *testing.T) {
+				jenkins.Spec.Master.PodDisruptionBudget.Enabled = false
+
+				require.NoError(t, reconciler.ensureJenkinsMasterPodDisruptionBudget(metaObject, gvk))
+
+				_, err := get()
+				assert.True(t, apierrors.IsNotFound(err))
+				assert.Equal(t, 1, countingClient.deletes)
+			})
+			t.Run("delete only once", func(t *testing.T) {
+				require.NoError(t, reconciler.ensureJenkinsMasterPodDisruptionBudget(metaObject, gvk))
+
+				assert.Equal(t, 1, countingClient.deletes)
+			})
+		})
+	}
+}
+
+type deleteCountingClient struct {
+	k8sclient.Client
+	deletes int
+}
+
+func (c *deleteCountingClient) Delete(ctx context.Context, obj k8sclient.Object, opts ...k8sclient.DeleteOption) error {
+	c.deletes++
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func TestCheckForDeferrablePodRecreation(t *testing.T) {
+	log.SetupLogger(true)
+	newReconciler := func(objects ...k8sclient.Object) (*JenkinsBaseConfigurationReconciler, corev1.Pod) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{PodDisruptionBudget: &v1alpha2.PodDisruptionBudget{Enabled: true}},
+			},
+			Status: v1alpha2.JenkinsStatus{OperatorVersion: version.Version},
+		}
+		jenkins.SetDefaults()
+		pod := resources.NewJenkinsMasterPod(resources.NewResourceObjectMeta(jenkins), jenkins)
+		pod.Spec.NodeName = "node"
+		fakeClient := fake.NewClientBuilder().WithObjects(objects...).Build()
+		return New(configuration.Configuration{
+			Client:    fakeClient,
+			APIReader: fakeClient,
+			Jenkins:   jenkins,
+			Scheme:    scheme.Scheme,
+		}, client.JenkinsAPIConnectionSettings{}), *pod
+	}
+
+	t.Run("schedulable node", func(t *testing.T) {
+		reconciler, pod := newReconciler(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}})
+
+		restartReason, err := reconciler.checkForDeferrablePodRecreation(pod)
+
+		require.NoError(t, err)
+		assert.False(t, restartReason.HasMessages())
+	})
+	t.Run("missing node", func(t *testing.T) {
+		reconciler, pod := newReconciler()
+
+		restartReason, err := reconciler.checkForDeferrablePodRecreation(pod)
+
+		require.NoError(t, err)
+		assert.False(t, restartReason.HasMessages())
+	})
+	t.Run("cordoned node", func(t *testing.T) {
+		reconciler, pod := newReconciler(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node"},
+			Spec:       corev1.NodeSpec{Unschedulable: true},
+		})
+
+		restartReason, err := reconciler.checkForDeferrablePodRecreation(pod)
+
+		require.NoError(t, err)
+		assert.Contains(t, restartReason.Short()[0], "Jenkins pod node is cordoned")
+	})
+	t.Run("affinity and topology spread constraints have changed", func(t *testing.T) {
+		reconciler, pod := newReconciler(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node"}})
+		reconciler.Configuration.Jenkins.Spec.Master.Affinity = &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					Weight:          100,
+					PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: corev1.LabelHostname},
+				}},
+			},
+		}
+		reconciler.Configuration.Jenkins.Spec.Master.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		}}
+
+		restartReason, err := reconciler.checkForDeferrablePodRecreation(pod)
+
+		require.NoError(t, err)
+		assert.Contains(t, restartReason.Short(), "Jenkins pod affinity has changed")
+		assert.Contains(t, restartReason.Short(), "Jenkins pod topology spread constraints have changed")
+	})
+}
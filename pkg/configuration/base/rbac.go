@@ -60,8 +60,7 @@ func (r *JenkinsBaseConfigurationReconciler) ensureExtraRBAC(meta metav1.ObjectM
 		return stackerr.WithStack(err)
 	}
 	for _, roleBinding := range roleBindings.Items {
-		if !strings.HasPrefix(roleBinding.Name, getExtraRoleBindingName(meta.Name, rbacv1.RoleRef{Kind: "Role"})) &&
-			!strings.HasPrefix(roleBinding.Name, getExtraRoleBindingName(meta.Name, rbacv1.RoleRef{Kind: "ClusterRole"})) {
+		if !isExtraRoleBinding(meta.Name, roleBinding.Name) {
 			continue
 		}
 
@@ -84,6 +83,31 @@ func (r *JenkinsBaseConfigurationReconciler) ensureExtraRBAC(meta metav1.ObjectM
 	return nil
 }
 
+// deleteExtraRoleBindings deletes RoleBindings created for spec.roles, they don't have the owner reference
+func (r *JenkinsBaseConfigurationReconciler) deleteExtraRoleBindings(meta metav1.ObjectMeta) error {
+	roleBindings := &rbacv1.RoleBindingList{}
+	err := r.Client.List(context.TODO(), roleBindings, client.InNamespace(meta.Namespace))
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+	for _, roleBinding := range roleBindings.Items {
+		if !isExtraRoleBinding(meta.Name, roleBinding.Name) {
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("Deleting RoleBinding '%s'", roleBinding.Name))
+		if err = r.Client.Delete(context.TODO(), &roleBinding); err != nil && !errors.IsNotFound(err) {
+			return stackerr.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func isExtraRoleBinding(serviceAccountName, roleBindingName string) bool {
+	return strings.HasPrefix(roleBindingName, getExtraRoleBindingName(serviceAccountName, rbacv1.RoleRef{Kind: "Role"})) ||
+		strings.HasPrefix(roleBindingName, getExtraRoleBindingName(serviceAccountName, rbacv1.RoleRef{Kind: "ClusterRole"}))
+}
+
 func getExtraRoleBindingName(serviceAccountName string, roleRef rbacv1.RoleRef) string {
 	var typeName string
 	if roleRef.Kind == "ClusterRole" {
@@ -3,6 +3,7 @@ package base
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/client"
@@ -13,10 +14,12 @@ import (
 	"github.com/bndr/gojenkins"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -994,3 +997,41 @@ func TestCompareContainerResources(t *testing.T) {
 		assert.False(t, got)
 	})
 }
+
+func TestJenkinsBaseConfigurationReconciler_ensurePlugins(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+	t.Run("restart deferred until the maintenance window", func(t *testing.T) {
+		now := time.Now().UTC()
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				MaintenanceWindows: []v1alpha2.TimeWindow{{
+					Start: now.Add(2 * time.Hour).Format(timeOfDayLayout),
+					End:   now.Add(3 * time.Hour).Format(timeOfDayLayout),
+				}},
+			},
+			Status: v1alpha2.JenkinsStatus{BaseConfigurationCompletedTime: &metav1.Time{Time: now}},
+		}
+		jenkins.SetDefaults()
+		pod := resources.NewJenkinsMasterPod(resources.NewResourceObjectMeta(jenkins), jenkins)
+		pod.Status.Phase = corev1.PodRunning
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins.DeepCopy(), pod).Build()
+		r := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(&gojenkins.Plugins{Raw: &gojenkins.PluginResponse{}}, nil)
+
+		restarting, err := r.ensurePlugins(jenkinsClient)
+
+		require.NoError(t, err)
+		assert.False(t, restarting)
+		if assert.NotNil(t, jenkins.Status.PendingRestart) {
+			assert.Contains(t, jenkins.Status.PendingRestart.Reasons[0], "Some plugins have changed")
+		}
+		// the Jenkins master pod hasn't been deleted
+		assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, &corev1.Pod{}))
+	})
+}
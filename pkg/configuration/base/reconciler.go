@@ -37,6 +37,8 @@ type JenkinsBaseConfigurationReconciler struct {
 	configuration.Configuration
 	logger                       logr.Logger
 	jenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
+	// restartDeferred tells if the Jenkins master pod restart has been deferred in the current reconcile loop
+	restartDeferred bool
 }
 
 // New create structure which takes care of base configuration
@@ -59,20 +61,11 @@ func (r *JenkinsBaseConfigurationReconciler) Reconcile() (reconcile.Result, jenk
 	}
 	r.logger.V(log.VDebug).Info("Kubernetes resources are present")
 
-	if useDeploymentForJenkinsMaster(r.Configuration.Jenkins) {
-		result, err := r.ensureJenkinsDeployment(metaObject)
-		if err != nil {
-			return reconcile.Result{}, nil, err
-		}
-		if result.Requeue {
-			return result, nil, nil
-		}
-		r.logger.V(log.VDebug).Info("Jenkins Deployment is present")
-
-		return result, nil, err
+	if err := r.updateMaintenanceWindowStatus(); err != nil {
+		return reconcile.Result{}, nil, err
 	}
 
-	result, err := r.ensureJenkinsMasterPod(metaObject)
+	result, err := r.ensureJenkinsMaster(metaObject)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
@@ -104,34 +97,53 @@ func (r *JenkinsBaseConfigurationReconciler) Reconcile() (reconcile.Result, jenk
 	}
 	r.logger.V(log.VDebug).Info("Jenkins API client set")
 
-	ok, err := r.verifyPlugins(jenkinsClient)
+	restarting, err := r.ensurePlugins(jenkinsClient)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
+	if restarting {
+		return reconcile.Result{Requeue: true}, nil, nil
+	}
+
+	result, err = r.ensureBaseConfiguration(jenkinsClient)
+
+	return result, jenkinsClient, err
+}
+
+// ensurePlugins restarts Jenkins when the installed plugins don't match the spec and tells if it's being restarted.
+// The deferred restart is recorded in the status, the operator keeps managing Jenkins running with the installed
+// plugins until the restart.
+func (r *JenkinsBaseConfigurationReconciler) ensurePlugins(jenkinsClient jenkinsclient.Jenkins) (bool, error) {
+	ok, err := r.verifyPlugins(jenkinsClient)
+	if err != nil {
+		return false, err
+	}
 	if !ok {
 		//TODO add what plugins have been changed
 		message := "Some plugins have changed, restarting Jenkins"
-		r.logger.Info(message)
 
 		restartReason := reason.NewPodRestart(
 			reason.OperatorSource,
 			[]string{message},
 		)
-		return reconcile.Result{Requeue: true}, nil, r.Configuration.RestartJenkinsMasterPod(restartReason)
+		deferred, err := r.deferRestart(restartReason)
+		if err != nil {
+			return false, err
+		}
+		if !deferred {
+			r.logger.Info(message)
+			return true, r.Configuration.RestartJenkinsMasterPod(restartReason)
+		}
 	}
 
-	result, err = r.ensureBaseConfiguration(jenkinsClient)
-
-	return result, jenkinsClient, err
-}
-
-func useDeploymentForJenkinsMaster(jenkins *v1alpha2.Jenkins) bool {
-	if val, ok := jenkins.Annotations["jenkins.io/use-deployment"]; ok {
-		if val == "true" {
-			return true
+	// the changes which required the pending restart have been reverted
+	if !r.restartDeferred {
+		if err := r.cancelPendingRestart(); err != nil {
+			return false, err
 		}
 	}
-	return false
+
+	return false, nil
 }
 
 func (r *JenkinsBaseConfigurationReconciler) ensureResourcesRequiredForJenkinsPod(metaObject metav1.ObjectMeta) error {
@@ -186,6 +198,15 @@ func (r *JenkinsBaseConfigurationReconciler) ensureResourcesRequiredForJenkinsPo
 	}
 	r.logger.V(log.VDebug).Info("Jenkins slave Service is present")
 
+	if gvk, found := resources.GetPodDisruptionBudgetGVK(&r.ClientSet); found {
+		if err := r.ensureJenkinsMasterPodDisruptionBudget(metaObject, gvk); err != nil {
+			return err
+		}
+		r.logger.V(log.VDebug).Info("Jenkins master PodDisruptionBudget is up to date")
+	} else if podDisruptionBudget := r.Configuration.Jenkins.Spec.Master.PodDisruptionBudget; podDisruptionBudget != nil && podDisruptionBudget.Enabled {
+		r.logger.V(log.VWarn).Info("spec.master.podDisruptionBudget is enabled but the policy API is not available, skipping PodDisruptionBudget")
+	}
+
 	if resources.IsRouteAPIAvailable(&r.ClientSet) {
 		r.logger.V(log.VDebug).Info("Route API is available. Now creating route.")
 		if err := r.createRoute(metaObject, httpServiceName, r.Configuration.Jenkins); err != nil {
@@ -194,6 +215,27 @@ func (r *JenkinsBaseConfigurationReconciler) ensureResourcesRequiredForJenkinsPo
 		r.logger.V(log.VDebug).Info("Jenkins Route is present")
 	}
 
+	if err := r.ensureIngress(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Jenkins Ingress is up to date")
+
+	if resources.IsGatewayAPIAvailable(&r.ClientSet) {
+		if err := r.ensureHTTPRoute(metaObject); err != nil {
+			return err
+		}
+		r.logger.V(log.VDebug).Info("Jenkins HTTPRoute is up to date")
+	}
+
+	if resources.IsMonitoringAPIAvailable(&r.ClientSet) {
+		if err := r.ensureMonitoring(metaObject); err != nil {
+			return err
+		}
+		r.logger.V(log.VDebug).Info("Jenkins ServiceMonitor and PrometheusRule are up to date")
+	} else if r.Configuration.Jenkins.Spec.Monitoring != nil {
+		r.logger.V(log.VWarn).Info("spec.monitoring is set but the monitoring.coreos.com API is not available, skipping ServiceMonitor and PrometheusRule")
+	}
+
 	return nil
 }
 
@@ -263,6 +305,13 @@ func compareMap(expected, actual map[string]string) bool {
 	return true
 }
 
+func compareTopologySpreadConstraints(expected, actual []corev1.TopologySpreadConstraint) bool {
+	if len(expected) == 0 && len(actual) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
 func compareEnv(expected, actual []corev1.EnvVar) bool {
 	var actualEnv []corev1.EnvVar
 	for _, env := range actual {
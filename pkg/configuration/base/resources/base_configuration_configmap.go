@@ -2,6 +2,7 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
@@ -19,6 +20,7 @@ const (
 	configureKubernetesPluginGroovyScriptName   = "6-configure-kubernetes-plugin.groovy"
 	configureViewsGroovyScriptName              = "7-configure-views.groovy"
 	disableJobDslScriptApprovalGroovyScriptName = "8-disable-job-dsl-script-approval.groovy"
+	configureLocationGroovyScriptName           = "9-configure-location.groovy"
 )
 
 const basicSettingsFmt = `
@@ -172,6 +174,23 @@ GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).useScript
 GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).save()
 `
 
+const configureLocationFmt = `
+import jenkins.model.JenkinsLocationConfiguration
+
+def location = JenkinsLocationConfiguration.get()
+if (location.getUrl() != %s) {
+    location.setUrl(%s)
+    location.save()
+}
+`
+
+var groovyStringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+
+// groovyStringLiteral returns s as a single-quoted Groovy string literal, which isn't interpolated
+func groovyStringLiteral(s string) string {
+	return "'" + groovyStringEscaper.Replace(s) + "'"
+}
+
 // GetBaseConfigurationConfigMapName returns name of Kubernetes config map used to base configuration.
 func GetBaseConfigurationConfigMapName(jenkins *v1alpha2.Jenkins) string {
 	return fmt.Sprintf("%s-base-configuration-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
@@ -215,6 +234,10 @@ func NewBaseConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jen
 	if jenkins.Spec.Master.DisableCSRFProtection {
 		delete(groovyScriptsMap, enableCSRFGroovyScriptName)
 	}
+	if externalURL := GetJenkinsExternalURL(jenkins); len(externalURL) > 0 {
+		url := groovyStringLiteral(externalURL)
+		groovyScriptsMap[configureLocationGroovyScriptName] = fmt.Sprintf(configureLocationFmt, url, url)
+	}
 	return &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
@@ -0,0 +1,23 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewJenkinsDeployment(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+	jenkins.SetDefaults()
+
+	deployment := NewJenkinsDeployment(NewResourceObjectMeta(jenkins), jenkins)
+
+	assert.Equal(t, "jenkins-example", deployment.Name)
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+	// the rolling update would start the second Jenkins master with the same Jenkins home volume
+	assert.Equal(t, appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}, deployment.Spec.Strategy)
+}
@@ -0,0 +1,170 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	// GatewayGroupVersion is the Gateway API group version
+	GatewayGroupVersion = schema.GroupVersion{Group: "gateway.networking.k8s.io", Version: "v1"}
+
+	// HTTPRouteGVK is the GroupVersionKind of the Gateway API HTTPRoute
+	HTTPRouteGVK = GatewayGroupVersion.WithKind("HTTPRoute")
+)
+
+var isGatewayAPIAvailable = false
+var gatewayAPIChecked = false
+var gatewayAPIMutex sync.Mutex
+
+// IsGatewayAPIAvailable tells if the Gateway API is installed and discoverable
+func IsGatewayAPIAvailable(clientSet *kubernetes.Clientset) bool {
+	return checkGatewayAPI(clientSet)
+}
+
+// checkGatewayAPI caches the result only when the discovery succeeded, a failed discovery is retried in the next call
+func checkGatewayAPI(client discovery.DiscoveryInterface) bool {
+	gatewayAPIMutex.Lock()
+	defer gatewayAPIMutex.Unlock()
+
+	if gatewayAPIChecked {
+		return isGatewayAPIAvailable
+	}
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return false
+	}
+	isGatewayAPIAvailable = false
+	for _, groupVersion := range metav1.ExtractGroupVersions(groups) {
+		if groupVersion == GatewayGroupVersion.String() {
+			isGatewayAPIAvailable = true
+		}
+	}
+	gatewayAPIChecked = true
+	return isGatewayAPIAvailable
+}
+
+// GetJenkinsIngressName returns name of the Ingress and the HTTPRoute exposing Jenkins
+func GetJenkinsIngressName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("jenkins-%s", jenkins.ObjectMeta.Name)
+}
+
+// GetJenkinsExternalURL returns the Jenkins root URL exposed by spec.ingress, empty when Jenkins isn't exposed
+func GetJenkinsExternalURL(jenkins *v1alpha2.Jenkins) string {
+	ingress := jenkins.Spec.Ingress
+	if ingress == nil {
+		return ""
+	}
+
+	scheme := "http"
+	if len(ingress.TLSSecretName) > 0 {
+		scheme = "https"
+	}
+	path := ingress.Path
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, ingress.Host, path)
+}
+
+// NewJenkinsIngress returns the Ingress routing spec.ingress host and path to the Jenkins HTTP service
+func NewJenkinsIngress(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *networkingv1.Ingress {
+	spec := jenkins.Spec.Ingress
+	meta.Name = GetJenkinsIngressName(jenkins)
+	meta.Annotations = spec.Annotations
+
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: meta,
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: spec.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     spec.Path,
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: GetJenkinsHTTPServiceName(jenkins),
+											Port: networkingv1.ServiceBackendPort{Number: jenkins.Spec.Service.Port},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if len(spec.ClassName) > 0 {
+		className := spec.ClassName
+		ingress.Spec.IngressClassName = &className
+	}
+	if len(spec.TLSSecretName) > 0 {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{spec.Host}, SecretName: spec.TLSSecretName}}
+	}
+
+	return ingress
+}
+
+// NewJenkinsHTTPRoute returns the Gateway API HTTPRoute routing spec.ingress host and path to the Jenkins HTTP service
+func NewJenkinsHTTPRoute(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *unstructured.Unstructured {
+	spec := jenkins.Spec.Ingress
+
+	parentRef := map[string]interface{}{}
+	if spec.Gateway != nil {
+		parentRef["name"] = spec.Gateway.Name
+		if len(spec.Gateway.Namespace) > 0 {
+			parentRef["namespace"] = spec.Gateway.Namespace
+		}
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(HTTPRouteGVK)
+	obj.SetName(GetJenkinsIngressName(jenkins))
+	obj.SetNamespace(meta.Namespace)
+	obj.SetLabels(meta.Labels)
+	obj.SetAnnotations(spec.Annotations)
+	obj.Object["spec"] = map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"hostnames":  []interface{}{spec.Host},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  "PathPrefix",
+							"value": spec.Path,
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": GetJenkinsHTTPServiceName(jenkins),
+						"port": int64(jenkins.Spec.Service.Port),
+					},
+				},
+			},
+		},
+	}
+
+	return obj
+}
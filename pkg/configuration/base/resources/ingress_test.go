@@ -0,0 +1,154 @@
+package resources
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newIngressJenkins(ingress *v1alpha2.Ingress) *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Service: v1alpha2.Service{Port: 8080},
+			Ingress: ingress,
+		},
+	}
+}
+
+func TestGetJenkinsExternalURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ingress *v1alpha2.Ingress
+		want    string
+	}{
+		{name: "not exposed", ingress: nil, want: ""},
+		{name: "http", ingress: &v1alpha2.Ingress{Host: "jenkins.example.com", Path: "/"}, want: "http://jenkins.example.com/"},
+		{name: "https with path", ingress: &v1alpha2.Ingress{Host: "jenkins.example.com", Path: "/jenkins", TLSSecretName: "jenkins-tls"}, want: "https://jenkins.example.com/jenkins/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetJenkinsExternalURL(newIngressJenkins(tt.ingress)))
+		})
+	}
+}
+
+func TestNewJenkinsIngress(t *testing.T) {
+	jenkins := newIngressJenkins(&v1alpha2.Ingress{
+		Host:          "jenkins.example.com",
+		Path:          "/jenkins",
+		TLSSecretName: "jenkins-tls",
+		ClassName:     "nginx",
+		Annotations:   map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "50m"},
+	})
+
+	ingress := NewJenkinsIngress(NewResourceObjectMeta(jenkins), jenkins)
+
+	assert.Equal(t, "jenkins-example", ingress.Name)
+	assert.Equal(t, "50m", ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"])
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"jenkins.example.com"}, SecretName: "jenkins-tls"}}, ingress.Spec.TLS)
+	path := ingress.Spec.Rules[0].HTTP.Paths[0]
+	assert.Equal(t, "/jenkins", path.Path)
+	assert.Equal(t, networkingv1.PathTypePrefix, *path.PathType)
+	assert.Equal(t, GetJenkinsHTTPServiceName(jenkins), path.Backend.Service.Name)
+	assert.Equal(t, int32(8080), path.Backend.Service.Port.Number)
+}
+
+func TestNewJenkinsHTTPRoute(t *testing.T) {
+	jenkins := newIngressJenkins(&v1alpha2.Ingress{
+		Type:    v1alpha2.HTTPRouteIngressType,
+		Host:    "jenkins.example.com",
+		Path:    "/",
+		Gateway: &v1alpha2.GatewayReference{Name: "gateway", Namespace: "infra"},
+	})
+
+	httpRoute := NewJenkinsHTTPRoute(NewResourceObjectMeta(jenkins), jenkins)
+
+	assert.Equal(t, HTTPRouteGVK, httpRoute.GroupVersionKind())
+	assert.Equal(t, "jenkins-example", httpRoute.GetName())
+	parentRefs, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "gateway", "namespace": "infra"}}, parentRefs)
+	rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name": GetJenkinsHTTPServiceName(jenkins),
+			"port": int64(8080),
+		},
+	}, rules[0].(map[string]interface{})["backendRefs"])
+}
+
+func TestNewBaseConfigurationConfigMap_JenkinsLocation(t *testing.T) {
+	t.Run("not exposed", func(t *testing.T) {
+		jenkins := newIngressJenkins(nil)
+		jenkins.SetDefaults()
+
+		configMap, err := NewBaseConfigurationConfigMap(NewResourceObjectMeta(jenkins), jenkins, "cluster.local")
+
+		assert.NoError(t, err)
+		assert.NotContains(t, configMap.Data, configureLocationGroovyScriptName)
+	})
+	t.Run("exposed", func(t *testing.T) {
+		jenkins := newIngressJenkins(&v1alpha2.Ingress{Host: "jenkins.example.com", Path: "/", TLSSecretName: "jenkins-tls"})
+		jenkins.SetDefaults()
+
+		configMap, err := NewBaseConfigurationConfigMap(NewResourceObjectMeta(jenkins), jenkins, "cluster.local")
+
+		assert.NoError(t, err)
+		assert.Contains(t, configMap.Data[configureLocationGroovyScriptName], `location.setUrl('https://jenkins.example.com/')`)
+	})
+	t.Run("escaped path", func(t *testing.T) {
+		jenkins := newIngressJenkins(&v1alpha2.Ingress{Host: "jenkins.example.com", Path: `/it's\${x}`})
+		jenkins.SetDefaults()
+
+		configMap, err := NewBaseConfigurationConfigMap(NewResourceObjectMeta(jenkins), jenkins, "cluster.local")
+
+		assert.NoError(t, err)
+		assert.Contains(t, configMap.Data[configureLocationGroovyScriptName], `location.setUrl('http://jenkins.example.com/it\'s\\${x}/')`)
+	})
+}
+
+type failingDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (failingDiscovery) ServerGroups() (*metav1.APIGroupList, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestCheckGatewayAPI(t *testing.T) {
+	reset := func() {
+		gatewayAPIChecked = false
+		isGatewayAPIAvailable = false
+	}
+	defer reset()
+	available := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metav1.APIResourceList{{GroupVersion: GatewayGroupVersion.String()}},
+	}}
+
+	t.Run("available", func(t *testing.T) {
+		reset()
+
+		assert.True(t, checkGatewayAPI(available))
+	})
+	t.Run("not available", func(t *testing.T) {
+		reset()
+
+		assert.False(t, checkGatewayAPI(&fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}))
+		assert.False(t, checkGatewayAPI(available))
+	})
+	t.Run("discovery error isn't cached", func(t *testing.T) {
+		reset()
+
+		assert.False(t, checkGatewayAPI(failingDiscovery{FakeDiscovery: available}))
+		assert.True(t, checkGatewayAPI(available))
+	})
+}
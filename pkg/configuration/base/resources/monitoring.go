@@ -0,0 +1,172 @@
+package resources
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultScrapeInterval = "30s"
+	defaultMetricsPath    = "/prometheus/"
+
+	// minBackupStaleSeconds is the minimal age of the last backup reported as stale
+	minBackupStaleSeconds = 3600
+)
+
+var (
+	// MonitoringGroupVersion is the Prometheus Operator API group version
+	MonitoringGroupVersion = schema.GroupVersion{Group: "monitoring.coreos.com", Version: "v1"}
+
+	// ServiceMonitorGVK is the GroupVersionKind of the Prometheus Operator ServiceMonitor
+	ServiceMonitorGVK = MonitoringGroupVersion.WithKind("ServiceMonitor")
+
+	// PrometheusRuleGVK is the GroupVersionKind of the Prometheus Operator PrometheusRule
+	PrometheusRuleGVK = MonitoringGroupVersion.WithKind("PrometheusRule")
+)
+
+var isMonitoringAPIAvailable = false
+var monitoringAPIChecked = false
+var monitoringAPIMutex sync.Mutex
+
+// IsMonitoringAPIAvailable tells if the Prometheus Operator API is installed and discoverable
+func IsMonitoringAPIAvailable(clientSet *kubernetes.Clientset) bool {
+	monitoringAPIMutex.Lock()
+	defer monitoringAPIMutex.Unlock()
+
+	if monitoringAPIChecked {
+		return isMonitoringAPIAvailable
+	}
+	if err := discovery.ServerSupportsVersion(clientSet, MonitoringGroupVersion); err != nil {
+		// error, API not available
+		monitoringAPIChecked = true
+		isMonitoringAPIAvailable = false
+	} else {
+		// API Exists
+		monitoringAPIChecked = true
+		isMonitoringAPIAvailable = true
+	}
+	return isMonitoringAPIAvailable
+}
+
+// GetMonitoringName returns name of the ServiceMonitor and the PrometheusRule
+func GetMonitoringName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+func newMonitoringObject(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(GetMonitoringName(jenkins))
+	obj.SetNamespace(meta.Namespace)
+
+	labels := map[string]string{}
+	for key, value := range meta.Labels {
+		labels[key] = value
+	}
+	for key, value := range jenkins.Spec.Monitoring.Labels {
+		labels[key] = value
+	}
+	obj.SetLabels(labels)
+
+	return obj
+}
+
+// NewServiceMonitor returns ServiceMonitor scraping the Jenkins Prometheus metrics plugin endpoint
+func NewServiceMonitor(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *unstructured.Unstructured {
+	interval := jenkins.Spec.Monitoring.ScrapeInterval
+	if len(interval) == 0 {
+		interval = defaultScrapeInterval
+	}
+	path := jenkins.Spec.Monitoring.MetricsPath
+	if len(path) == 0 {
+		path = defaultMetricsPath
+	}
+
+	selector := map[string]interface{}{}
+	for key, value := range BuildResourceLabels(jenkins) {
+		selector[key] = value
+	}
+
+	obj := newMonitoringObject(meta, jenkins, ServiceMonitorGVK)
+	obj.Object["spec"] = map[string]interface{}{
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				// the service port is not named, slave service endpoints don't expose this port
+				"targetPort": int64(constants.DefaultHTTPPortInt32),
+				"path":       path,
+				"interval":   interval,
+			},
+		},
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []interface{}{meta.Namespace},
+		},
+		"selector": map[string]interface{}{
+			"matchLabels": selector,
+		},
+	}
+
+	return obj
+}
+
+func newAlert(name, expr, duration, severity, summary string) map[string]interface{} {
+	return map[string]interface{}{
+		"alert": name,
+		"expr":  expr,
+		"for":   duration,
+		"labels": map[string]interface{}{
+			"severity": severity,
+		},
+		"annotations": map[string]interface{}{
+			"summary": summary,
+		},
+	}
+}
+
+// NewPrometheusRule returns PrometheusRule with the default Jenkins alerts. Queue stuck alert requires
+// spec.runtimeMetrics and backup stale alert requires spec.backup, both use the operator metrics
+// which must be scraped with honorLabels.
+func NewPrometheusRule(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *unstructured.Unstructured {
+	cr := fmt.Sprintf("%s/%s", jenkins.Namespace, jenkins.Name)
+	operatorSelector := fmt.Sprintf(`namespace="%s", name="%s"`, jenkins.Namespace, jenkins.Name)
+
+	rules := []interface{}{
+		newAlert("JenkinsDown",
+			fmt.Sprintf(`absent(up{namespace="%s", service="%s"} == 1)`, jenkins.Namespace, GetJenkinsHTTPServiceName(jenkins)),
+			"5m", "critical", fmt.Sprintf("Jenkins %s is down", cr)),
+	}
+	if jenkins.Spec.RuntimeMetrics != nil {
+		rules = append(rules, newAlert("JenkinsQueueStuck",
+			fmt.Sprintf(`min_over_time(jenkins_operator_jenkins_queue_items{%s}[30m]) > 0`, operatorSelector),
+			"5m", "warning", fmt.Sprintf("Jenkins %s build queue has not been empty for 30 minutes", cr)))
+	}
+	if jenkins.Spec.Backup.Interval > 0 {
+		staleSeconds := 3 * jenkins.Spec.Backup.Interval
+		if staleSeconds < minBackupStaleSeconds {
+			staleSeconds = minBackupStaleSeconds
+		}
+		rules = append(rules, newAlert("JenkinsBackupStale",
+			fmt.Sprintf(`time() - jenkins_operator_last_successful_backup_timestamp_seconds{%s} > %d`, operatorSelector, staleSeconds),
+			"5m", "warning", fmt.Sprintf("Jenkins %s has not been backed up for more than %d seconds", cr, staleSeconds)))
+	}
+
+	obj := newMonitoringObject(meta, jenkins, PrometheusRuleGVK)
+	obj.Object["spec"] = map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  GetMonitoringName(jenkins),
+				"rules": rules,
+			},
+		},
+	}
+
+	return obj
+}
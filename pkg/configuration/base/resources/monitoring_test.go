@@ -0,0 +1,71 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewServiceMonitor(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Monitoring: &v1alpha2.Monitoring{Labels: map[string]string{"release": "prometheus"}},
+		},
+	}
+
+	serviceMonitor := NewServiceMonitor(NewResourceObjectMeta(jenkins), jenkins)
+
+	assert.Equal(t, "jenkins-operator-example", serviceMonitor.GetName())
+	assert.Equal(t, "prometheus", serviceMonitor.GetLabels()["release"])
+	assert.Equal(t, "jenkins-operator", serviceMonitor.GetLabels()["app"])
+	endpoints, _, _ := unstructured.NestedSlice(serviceMonitor.Object, "spec", "endpoints")
+	assert.Equal(t, map[string]interface{}{
+		"targetPort": int64(8080),
+		"path":       "/prometheus/",
+		"interval":   "30s",
+	}, endpoints[0])
+	matchLabels, _, _ := unstructured.NestedStringMap(serviceMonitor.Object, "spec", "selector", "matchLabels")
+	assert.Equal(t, BuildResourceLabels(jenkins), matchLabels)
+}
+
+func TestNewPrometheusRule(t *testing.T) {
+	alertNames := func(obj *unstructured.Unstructured) []string {
+		groups, _, _ := unstructured.NestedSlice(obj.Object, "spec", "groups")
+		rules := groups[0].(map[string]interface{})["rules"].([]interface{})
+		var names []string
+		for _, rule := range rules {
+			names = append(names, rule.(map[string]interface{})["alert"].(string))
+		}
+		return names
+	}
+
+	t.Run("only Jenkins down", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec:       v1alpha2.JenkinsSpec{Monitoring: &v1alpha2.Monitoring{}},
+		}
+
+		rule := NewPrometheusRule(NewResourceObjectMeta(jenkins), jenkins)
+
+		assert.Equal(t, []string{"JenkinsDown"}, alertNames(rule))
+	})
+	t.Run("all alerts", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Monitoring:     &v1alpha2.Monitoring{},
+				RuntimeMetrics: &v1alpha2.RuntimeMetrics{},
+				Backup:         v1alpha2.Backup{Interval: 30},
+			},
+		}
+
+		rule := NewPrometheusRule(NewResourceObjectMeta(jenkins), jenkins)
+
+		assert.Equal(t, []string{"JenkinsDown", "JenkinsQueueStuck", "JenkinsBackupStale"}, alertNames(rule))
+	})
+}
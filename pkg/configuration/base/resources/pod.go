@@ -13,16 +13,16 @@ import (
 
 const (
 	// JenkinsMasterContainerName is the Jenkins master container name in pod
-	JenkinsMasterContainerName = "jenkins-master"
+	JenkinsMasterContainerName = constants.JenkinsMasterContainerName
 	// JenkinsHomeVolumeName is the Jenkins home volume name
 	JenkinsHomeVolumeName    = "jenkins-home"
 	jenkinsPath              = "/var/jenkins"
 	httpGetPath              = "/login"
 	jenkinsScriptsVolumeName = "scripts"
 	// JenkinsScriptsVolumePath is a path where are scripts used to configure Jenkins
-	JenkinsScriptsVolumePath = jenkinsPath + "/scripts"
+	JenkinsScriptsVolumePath = constants.JenkinsScriptsVolumePath
 	// InitScriptName is the init script name which configures init.groovy.d, scripts and install plugins
-	InitScriptName = "init.sh"
+	InitScriptName = constants.InitScriptName
 
 	jenkinsOperatorCredentialsVolumeName = "operator-credentials"
 	jenkinsOperatorCredentialsVolumePath = jenkinsPath + "/operator-credentials"
@@ -367,20 +367,38 @@ func NewJenkinsMasterPod(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins
 	objectMeta.Name = GetJenkinsMasterPodName(jenkins)
 	objectMeta.Labels = GetJenkinsMasterPodLabels(*jenkins)
 
+	podSpec := newJenkinsMasterPodSpec(serviceAccountName, jenkins)
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
 	return &corev1.Pod{
 		TypeMeta:   buildPodTypeMeta(),
 		ObjectMeta: objectMeta,
-		Spec: corev1.PodSpec{
-			ServiceAccountName: serviceAccountName,
-			RestartPolicy:      corev1.RestartPolicyNever,
-			NodeSelector:       jenkins.Spec.Master.NodeSelector,
-			Containers:         newContainers(jenkins),
-			Volumes:            append(GetJenkinsMasterPodBaseVolumes(jenkins), jenkins.Spec.Master.Volumes...),
-			SecurityContext:    jenkins.Spec.Master.SecurityContext,
-			ImagePullSecrets:   jenkins.Spec.Master.ImagePullSecrets,
-			Tolerations:        jenkins.Spec.Master.Tolerations,
-			PriorityClassName:  jenkins.Spec.Master.PriorityClassName,
-			HostAliases:        jenkins.Spec.Master.HostAliases,
+		Spec:       podSpec,
+	}
+}
+
+// NewJenkinsMasterPodTemplateSpec builds Jenkins Master pod template of the Deployment or StatefulSet
+func NewJenkinsMasterPodTemplateSpec(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      GetJenkinsMasterPodLabels(*jenkins),
+			Annotations: jenkins.Spec.Master.Annotations,
 		},
+		Spec: newJenkinsMasterPodSpec(objectMeta.Name, jenkins),
+	}
+}
+
+func newJenkinsMasterPodSpec(serviceAccountName string, jenkins *v1alpha2.Jenkins) corev1.PodSpec {
+	return corev1.PodSpec{
+		ServiceAccountName:        serviceAccountName,
+		NodeSelector:              jenkins.Spec.Master.NodeSelector,
+		Containers:                newContainers(jenkins),
+		Volumes:                   append(GetJenkinsMasterPodBaseVolumes(jenkins), jenkins.Spec.Master.Volumes...),
+		SecurityContext:           jenkins.Spec.Master.SecurityContext,
+		ImagePullSecrets:          jenkins.Spec.Master.ImagePullSecrets,
+		Tolerations:               jenkins.Spec.Master.Tolerations,
+		Affinity:                  jenkins.Spec.Master.Affinity,
+		PriorityClassName:         jenkins.Spec.Master.PriorityClassName,
+		HostAliases:               jenkins.Spec.Master.HostAliases,
+		TopologySpreadConstraints: jenkins.Spec.Master.TopologySpreadConstraints,
 	}
 }
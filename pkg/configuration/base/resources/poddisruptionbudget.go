@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"sync"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	// PodDisruptionBudgetV1GVK is the GroupVersionKind of the PodDisruptionBudget served since Kubernetes 1.21
+	PodDisruptionBudgetV1GVK = schema.GroupVersionKind{Group: "policy", Version: "v1", Kind: "PodDisruptionBudget"}
+
+	// PodDisruptionBudgetV1beta1GVK is the GroupVersionKind of the PodDisruptionBudget removed in Kubernetes 1.25
+	PodDisruptionBudgetV1beta1GVK = schema.GroupVersionKind{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget"}
+)
+
+var podDisruptionBudgetGVK schema.GroupVersionKind
+var isPodDisruptionBudgetAPIAvailable = false
+var podDisruptionBudgetAPIChecked = false
+var podDisruptionBudgetAPIMutex sync.Mutex
+
+// GetPodDisruptionBudgetGVK returns the PodDisruptionBudget GroupVersionKind served by the cluster, policy/v1 is
+// preferred over policy/v1beta1. The second value tells if any of them is available.
+func GetPodDisruptionBudgetGVK(clientSet *kubernetes.Clientset) (schema.GroupVersionKind, bool) {
+	podDisruptionBudgetAPIMutex.Lock()
+	defer podDisruptionBudgetAPIMutex.Unlock()
+
+	if podDisruptionBudgetAPIChecked {
+		return podDisruptionBudgetGVK, isPodDisruptionBudgetAPIAvailable
+	}
+	podDisruptionBudgetAPIChecked = true
+	for _, gvk := range []schema.GroupVersionKind{PodDisruptionBudgetV1GVK, PodDisruptionBudgetV1beta1GVK} {
+		if err := discovery.ServerSupportsVersion(clientSet, gvk.GroupVersion()); err == nil {
+			// API Exists
+			podDisruptionBudgetGVK = gvk
+			isPodDisruptionBudgetAPIAvailable = true
+			break
+		}
+	}
+	return podDisruptionBudgetGVK, isPodDisruptionBudgetAPIAvailable
+}
+
+// NewJenkinsMasterPodDisruptionBudget builds the PodDisruptionBudget which blocks the eviction of the Jenkins master pod
+func NewJenkinsMasterPodDisruptionBudget(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	matchLabels := map[string]interface{}{}
+	for key, value := range BuildResourceLabels(jenkins) {
+		matchLabels[key] = value
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(GetJenkinsMasterPodDisruptionBudgetName(jenkins))
+	obj.SetNamespace(objectMeta.Namespace)
+	obj.SetLabels(objectMeta.Labels)
+	obj.Object["spec"] = map[string]interface{}{
+		"minAvailable": int64(1),
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+	}
+
+	return obj
+}
+
+// GetJenkinsMasterPodDisruptionBudgetName returns Jenkins master PodDisruptionBudget name for given CR
+func GetJenkinsMasterPodDisruptionBudgetName(jenkins *v1alpha2.Jenkins) string {
+	return GetJenkinsMasterPodName(jenkins)
+}
@@ -1,6 +1,8 @@
 package resources
 
 import (
+	"sync"
+
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	routev1 "github.com/openshift/api/route/v1"
 
@@ -12,6 +14,7 @@ import (
 
 var isRouteAPIAvailable = false
 var routeAPIChecked = false
+var routeAPIMutex sync.Mutex
 
 // UpdateRoute returns new route matching the service
 func UpdateRoute(actual routev1.Route, jenkins *v1alpha2.Jenkins) routev1.Route {
@@ -29,6 +32,9 @@ func UpdateRoute(actual routev1.Route, jenkins *v1alpha2.Jenkins) routev1.Route
 
 //IsRouteAPIAvailable tells if the Route API is installed and discoverable
 func IsRouteAPIAvailable(clientSet *kubernetes.Clientset) bool {
+	routeAPIMutex.Lock()
+	defer routeAPIMutex.Unlock()
+
 	if routeAPIChecked {
 		return isRouteAPIAvailable
 	}
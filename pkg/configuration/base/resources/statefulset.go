@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// NewJenkinsStatefulSet builds Jenkins Master Kubernetes StatefulSet resource.
+func NewJenkinsStatefulSet(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *appsv1.StatefulSet {
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetJenkinsStatefulSetName(jenkins),
+			Namespace: objectMeta.Namespace,
+			Labels:    objectMeta.Labels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:       pointer.Int32Ptr(1),
+			ServiceName:    GetJenkinsHTTPServiceName(jenkins),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+			Template:       NewJenkinsMasterPodTemplateSpec(objectMeta, jenkins),
+			Selector:       &metav1.LabelSelector{MatchLabels: BuildResourceLabels(jenkins)},
+		},
+	}
+
+	if jenkins.Spec.Master.Storage != nil {
+		// Jenkins home is mounted from the persistent volume claim instead of the empty dir
+		var volumes []corev1.Volume
+		for _, volume := range statefulSet.Spec.Template.Spec.Volumes {
+			if volume.Name != JenkinsHomeVolumeName {
+				volumes = append(volumes, volume)
+			}
+		}
+		statefulSet.Spec.Template.Spec.Volumes = volumes
+		statefulSet.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{NewJenkinsHomePersistentVolumeClaimTemplate(jenkins)}
+	}
+
+	return statefulSet
+}
+
+// NewJenkinsHomePersistentVolumeClaimTemplate builds the StatefulSet volume claim template of the Jenkins home directory
+func NewJenkinsHomePersistentVolumeClaimTemplate(jenkins *v1alpha2.Jenkins) corev1.PersistentVolumeClaim {
+	storage := jenkins.Spec.Master.Storage
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   JenkinsHomeVolumeName,
+			Labels: BuildResourceLabels(jenkins),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      storage.AccessModes,
+			StorageClassName: storage.StorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: storage.Size},
+			},
+		},
+	}
+}
+
+// GetJenkinsStatefulSetName returns Jenkins StatefulSet name for given CR
+func GetJenkinsStatefulSetName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("jenkins-%s", jenkins.Name)
+}
+
+// GetJenkinsStatefulSetPodName returns name of the Jenkins pod managed by the StatefulSet for given CR
+func GetJenkinsStatefulSetPodName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-0", GetJenkinsStatefulSetName(jenkins))
+}
+
+// GetJenkinsHomePersistentVolumeClaimName returns name of the Jenkins home persistent volume claim created by the StatefulSet
+func GetJenkinsHomePersistentVolumeClaimName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-%s", JenkinsHomeVolumeName, GetJenkinsStatefulSetPodName(jenkins))
+}
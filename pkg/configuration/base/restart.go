@@ -0,0 +1,224 @@
+package base
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PendingRestartCheckInterval is how often the operator checks if the deferred Jenkins master pod restart can be executed
+const PendingRestartCheckInterval = 30 * time.Second
+
+const (
+	conditionReasonWaitingForIdle              = "WaitingForIdle"
+	conditionReasonWaitingForMaintenanceWindow = "WaitingForMaintenanceWindow"
+
+	quietDownScript       = "Jenkins.instance.doQuietDown()"
+	cancelQuietDownScript = "Jenkins.instance.doCancelQuietDown()"
+	busyExecutorsScript   = `def busyExecutors = 0
+Jenkins.instance.computers.each { computer ->
+    busyExecutors += computer.countBusy()
+    busyExecutors += computer.oneOffExecutors.count { it.busy }
+}
+println "busyExecutors=${busyExecutors}"`
+)
+
+var busyExecutorsRegexp = regexp.MustCompile(`busyExecutors=(\d+)`)
+
+// deferRestart tells if the Jenkins master pod restart has to wait according to spec.maintenanceWindows and
+// spec.restartPolicy. The deferred restart is reported in the status and Jenkins is put in quiet-down mode,
+// so it doesn't start new builds.
+func (r *JenkinsBaseConfigurationReconciler) deferRestart(restartReason reason.Reason) (bool, error) {
+	jenkins := r.Configuration.Jenkins
+	restartPolicy := jenkins.Spec.RestartPolicy
+	if restartPolicy == nil {
+		restartPolicy = &v1alpha2.RestartPolicy{Type: v1alpha2.ImmediateRestartPolicyType}
+	}
+	if isImmediateRestartPolicy(*restartPolicy) && len(jenkins.Spec.MaintenanceWindows) == 0 {
+		return false, nil
+	}
+
+	observedPendingRestart := jenkins.Status.PendingRestart.DeepCopy()
+	observedConditions := append([]metav1.Condition{}, jenkins.Status.Conditions...)
+
+	pendingRestart := jenkins.Status.PendingRestart
+	if pendingRestart == nil {
+		pendingRestart = &v1alpha2.PendingRestart{Since: metav1.Now()}
+	}
+	if r.restartDeferred {
+		// the restart has been already deferred for other reasons in this reconcile loop
+		for _, msg := range restartReason.Short() {
+			if !containsString(pendingRestart.Reasons, msg) {
+				pendingRestart.Reasons = append(pendingRestart.Reasons, msg)
+			}
+		}
+	} else {
+		pendingRestart.Reasons = restartReason.Short()
+	}
+
+	deferred, conditionReason, message, err := r.isRestartDeferred(*restartPolicy, pendingRestart)
+	if err != nil {
+		return false, err
+	}
+	if deferred {
+		if observedPendingRestart == nil {
+			r.logger.Info(fmt.Sprintf("Jenkins master pod restart has been deferred: %s", message))
+		}
+		r.restartDeferred = true
+		jenkins.Status.PendingRestart = pendingRestart
+		jenkins.SetCondition(v1alpha2.ConditionRestartPending, metav1.ConditionTrue, conditionReason, message)
+	} else {
+		// the restart is executed now, the pod is recreated without quiet-down mode
+		r.restartDeferred = false
+		jenkins.Status.PendingRestart = nil
+		jenkins.RemoveCondition(v1alpha2.ConditionRestartPending)
+	}
+
+	if !reflect.DeepEqual(observedPendingRestart, jenkins.Status.PendingRestart) || !reflect.DeepEqual(observedConditions, jenkins.Status.Conditions) {
+		if err := configuration.UpdateJenkinsStatus(r.Client, jenkins); err != nil {
+			return false, stackerr.WithStack(err)
+		}
+	}
+
+	return deferred, nil
+}
+
+func isImmediateRestartPolicy(restartPolicy v1alpha2.RestartPolicy) bool {
+	return restartPolicy.Type == "" || restartPolicy.Type == v1alpha2.ImmediateRestartPolicyType
+}
+
+func (r *JenkinsBaseConfigurationReconciler) isRestartDeferred(restartPolicy v1alpha2.RestartPolicy, pendingRestart *v1alpha2.PendingRestart) (bool, string, string, error) {
+	// there are no builds to wait for when Jenkins isn't up and running
+	if r.Configuration.Jenkins.Status.BaseConfigurationCompletedTime == nil {
+		return false, "", "", nil
+	}
+	currentJenkinsMasterPod, err := r.Configuration.GetJenkinsMasterPod()
+	if err != nil && apierrors.IsNotFound(err) {
+		return false, "", "", nil
+	} else if err != nil {
+		return false, "", "", stackerr.WithStack(err)
+	}
+	if !isPodReady(*currentJenkinsMasterPod) {
+		return false, "", "", nil
+	}
+
+	// the MaintenanceWindow restart policy waits for spec.maintenanceWindows like other policies
+	outside, message, err := r.isOutsideMaintenanceWindow(time.Now())
+	if err != nil {
+		return false, "", "", err
+	}
+	if outside {
+		return true, conditionReasonWaitingForMaintenanceWindow, message, r.leaveQuietDown(pendingRestart)
+	}
+	if isImmediateRestartPolicy(restartPolicy) {
+		return false, "", "", nil
+	}
+
+	if !pendingRestart.QuietDown {
+		if err := r.executeScript(quietDownScript); err != nil {
+			return false, "", "", err
+		}
+		pendingRestart.QuietDown = true
+	}
+
+	busyExecutors, err := r.countBusyExecutors()
+	if err != nil {
+		return false, "", "", err
+	}
+	if busyExecutors > 0 {
+		message := fmt.Sprintf("Jenkins is in quiet-down mode, waiting for %d running builds", busyExecutors)
+		return true, conditionReasonWaitingForIdle, message, nil
+	}
+
+	return false, "", "", nil
+}
+
+// leaveQuietDown cancels quiet-down mode, builds run normally until the maintenance window
+func (r *JenkinsBaseConfigurationReconciler) leaveQuietDown(pendingRestart *v1alpha2.PendingRestart) error {
+	if !pendingRestart.QuietDown {
+		return nil
+	}
+	if err := r.executeScript(cancelQuietDownScript); err != nil {
+		return err
+	}
+	pendingRestart.QuietDown = false
+	return nil
+}
+
+// cancelPendingRestart clears the pending restart when the changes which required it have been reverted
+func (r *JenkinsBaseConfigurationReconciler) cancelPendingRestart() error {
+	jenkins := r.Configuration.Jenkins
+	if jenkins.Status.PendingRestart == nil {
+		return nil
+	}
+
+	if err := r.leaveQuietDown(jenkins.Status.PendingRestart); err != nil {
+		return err
+	}
+	jenkins.Status.PendingRestart = nil
+	jenkins.RemoveCondition(v1alpha2.ConditionRestartPending)
+	r.logger.Info("Pending Jenkins master pod restart has been cancelled")
+
+	return stackerr.WithStack(configuration.UpdateJenkinsStatus(r.Client, jenkins))
+}
+
+func (r *JenkinsBaseConfigurationReconciler) executeScript(script string) error {
+	jenkinsClient, err := r.Configuration.GetJenkinsClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = jenkinsClient.ExecuteScript(script)
+	return stackerr.WithStack(err)
+}
+
+func (r *JenkinsBaseConfigurationReconciler) countBusyExecutors() (int, error) {
+	jenkinsClient, err := r.Configuration.GetJenkinsClient()
+	if err != nil {
+		return 0, err
+	}
+
+	output, err := jenkinsClient.ExecuteScript(busyExecutorsScript)
+	if err != nil {
+		return 0, stackerr.WithStack(err)
+	}
+	matches := busyExecutorsRegexp.FindStringSubmatch(output)
+	if len(matches) != 2 {
+		return 0, stackerr.Errorf("couldn't find busy executors in the script output '%s'", output)
+	}
+
+	return strconv.Atoi(matches[1])
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if !containerStatus.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package base
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDeferRestart(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	restartReason := reason.NewPodRestart(reason.OperatorSource, []string{"Jenkins pod node selector has changed"})
+	newReconciler := func(restartPolicy *v1alpha2.RestartPolicy) *JenkinsBaseConfigurationReconciler {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: defaultNamespace},
+			Spec:       v1alpha2.JenkinsSpec{RestartPolicy: restartPolicy},
+			Status:     v1alpha2.JenkinsStatus{BaseConfigurationCompletedTime: &metav1.Time{Time: time.Now()}},
+		}
+		jenkins.SetDefaults()
+		pod := resources.NewJenkinsMasterPod(resources.NewResourceObjectMeta(jenkins), jenkins)
+		pod.Status.Phase = corev1.PodRunning
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins.DeepCopy(), pod).Build()
+		return New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("immediate", func(t *testing.T) {
+		reconciler := newReconciler(nil)
+
+		deferred, err := reconciler.deferRestart(restartReason)
+
+		require.NoError(t, err)
+		assert.False(t, deferred)
+		assert.Nil(t, reconciler.Configuration.Jenkins.Status.PendingRestart)
+	})
+	t.Run("Jenkins isn't configured", func(t *testing.T) {
+		reconciler := newReconciler(&v1alpha2.RestartPolicy{Type: v1alpha2.WaitForIdleRestartPolicyType})
+		reconciler.Configuration.Jenkins.Status.BaseConfigurationCompletedTime = nil
+
+		deferred, err := reconciler.deferRestart(restartReason)
+
+		require.NoError(t, err)
+		assert.False(t, deferred)
+		assert.Nil(t, reconciler.Configuration.Jenkins.Status.PendingRestart)
+	})
+	t.Run("outside spec.maintenanceWindows", func(t *testing.T) {
+		reconciler := newReconciler(nil)
+		jenkins := reconciler.Configuration.Jenkins
+		now := time.Now().UTC()
+		jenkins.Spec.MaintenanceWindows = []v1alpha2.TimeWindow{{
+			Start: now.Add(2 * time.Hour).Format(timeOfDayLayout),
+			End:   now.Add(3 * time.Hour).Format(timeOfDayLayout),
+		}}
+
+		deferred, err := reconciler.deferRestart(restartReason)
+
+		require.NoError(t, err)
+		assert.True(t, deferred)
+		assert.NotNil(t, jenkins.Status.PendingRestart)
+
+		jenkins.Annotations = map[string]string{constants.MaintenanceWindowOverrideAnnotation: "true"}
+
+		deferred, err = reconciler.deferRestart(restartReason)
+
+		require.NoError(t, err)
+		assert.False(t, deferred)
+		assert.Nil(t, jenkins.Status.PendingRestart)
+		assert.Nil(t, meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.ConditionRestartPending))
+	})
+	t.Run("outside maintenance window", func(t *testing.T) {
+		reconciler := newReconciler(&v1alpha2.RestartPolicy{Type: v1alpha2.MaintenanceWindowRestartPolicyType})
+		jenkins := reconciler.Configuration.Jenkins
+		now := time.Now().UTC()
+		jenkins.Spec.MaintenanceWindows = []v1alpha2.TimeWindow{{
+			Start: now.Add(2 * time.Hour).Format(timeOfDayLayout),
+			End:   now.Add(3 * time.Hour).Format(timeOfDayLayout),
+		}}
+
+		deferred, err := reconciler.deferRestart(restartReason)
+
+		require.NoError(t, err)
+		assert.True(t, deferred)
+		if assert.NotNil(t, jenkins.Status.PendingRestart) {
+			assert.Equal(t, restartReason.Short(), jenkins.Status.PendingRestart.Reasons)
+			assert.False(t, jenkins.Status.PendingRestart.QuietDown)
+		}
+		condition := meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.ConditionRestartPending)
+		if assert.NotNil(t, condition) {
+			assert.Equal(t, metav1.ConditionTrue, condition.Status)
+			assert.Equal(t, conditionReasonWaitingForMaintenanceWindow, condition.Reason)
+		}
+		persisted := &v1alpha2.Jenkins{}
+		require.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Namespace: defaultNamespace, Name: jenkins.Name}, persisted))
+		assert.NotNil(t, persisted.Status.PendingRestart)
+
+		// changes have been reverted
+		require.NoError(t, reconciler.cancelPendingRestart())
+
+		assert.Nil(t, jenkins.Status.PendingRestart)
+		assert.Nil(t, meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.ConditionRestartPending))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
 	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 
 	docker "github.com/docker/distribution/reference"
@@ -16,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 var (
@@ -30,6 +32,24 @@ func (r *JenkinsBaseConfigurationReconciler) Validate(jenkins *v1alpha2.Jenkins)
 		messages = append(messages, msg...)
 	}
 
+	if msg := r.validateStorage(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateRestartPolicy(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateMaintenanceWindows(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	// the Gateway API is discovered only when the HTTPRoute is requested
+	isHTTPRoute := jenkins.Spec.Ingress != nil && jenkins.Spec.Ingress.Type == v1alpha2.HTTPRouteIngressType
+	if msg := r.validateIngress(isHTTPRoute && resources.IsGatewayAPIAvailable(&r.ClientSet)); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
 	if msg, err := r.validateVolumes(); err != nil {
 		return nil, err
 	} else if len(msg) > 0 {
@@ -63,6 +83,10 @@ func (r *JenkinsBaseConfigurationReconciler) Validate(jenkins *v1alpha2.Jenkins)
 		messages = append(messages, msg...)
 	}
 
+	if msg := r.validateNotifications(jenkins.Spec.Notifications); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
 	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy && jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.ServiceAccountAuthorizationStrategy {
 		messages = append(messages, fmt.Sprintf("unrecognized '%s' spec.jenkinsAPISettings.authorizationStrategy", jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy))
 	}
@@ -170,6 +194,84 @@ func (r *JenkinsBaseConfigurationReconciler) validateVolumes() ([]string, error)
 	return messages, nil
 }
 
+func (r *JenkinsBaseConfigurationReconciler) validateStorage() []string {
+	var messages []string
+	storage := r.Configuration.Jenkins.Spec.Master.Storage
+	if storage == nil {
+		return messages
+	}
+
+	if r.Configuration.Jenkins.Spec.Master.WorkloadType != v1alpha2.StatefulSetWorkloadType {
+		messages = append(messages, "spec.master.storage requires spec.master.workloadType StatefulSet")
+	}
+	if storage.Size.Sign() <= 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.storage.size '%s' must be greater than zero", storage.Size.String()))
+	}
+
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateRestartPolicy() []string {
+	var messages []string
+	restartPolicy := r.Configuration.Jenkins.Spec.RestartPolicy
+	if restartPolicy == nil {
+		return messages
+	}
+
+	switch restartPolicy.Type {
+	case v1alpha2.ImmediateRestartPolicyType, v1alpha2.WaitForIdleRestartPolicyType:
+	case v1alpha2.MaintenanceWindowRestartPolicyType:
+		if len(r.Configuration.Jenkins.Spec.MaintenanceWindows) == 0 {
+			messages = append(messages, "spec.maintenanceWindows is required by the MaintenanceWindow restart policy")
+		}
+	default:
+		messages = append(messages, fmt.Sprintf("unrecognized '%s' spec.restartPolicy.type", restartPolicy.Type))
+	}
+
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateMaintenanceWindows() []string {
+	var messages []string
+	for i, window := range r.Configuration.Jenkins.Spec.MaintenanceWindows {
+		if _, err := newTimeWindow(window); err != nil {
+			messages = append(messages, fmt.Sprintf("spec.maintenanceWindows[%d] is invalid: %s", i, err))
+		}
+	}
+
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateIngress(gatewayAPIAvailable bool) []string {
+	ingress := r.Configuration.Jenkins.Spec.Ingress
+	if ingress == nil {
+		return nil
+	}
+
+	var messages []string
+	if len(ingress.Host) == 0 {
+		messages = append(messages, "spec.ingress.host must be set")
+	} else if errs := validation.IsDNS1123Subdomain(ingress.Host); len(errs) > 0 {
+		messages = append(messages, fmt.Sprintf("spec.ingress.host '%s' is invalid: %s", ingress.Host, strings.Join(errs, ", ")))
+	}
+	if len(ingress.Path) > 0 && !strings.HasPrefix(ingress.Path, "/") {
+		messages = append(messages, "spec.ingress.path must start with '/'")
+	}
+	if ingress.Type == v1alpha2.HTTPRouteIngressType {
+		if !gatewayAPIAvailable {
+			messages = append(messages, fmt.Sprintf("spec.ingress.type HTTPRoute requires the Gateway API %s, which isn't available in the cluster", resources.GatewayGroupVersion))
+		}
+		if ingress.Gateway == nil || len(ingress.Gateway.Name) == 0 {
+			messages = append(messages, "spec.ingress.gateway.name is required by the HTTPRoute type")
+		}
+		if len(ingress.ClassName) > 0 {
+			messages = append(messages, "spec.ingress.className can't be used with the HTTPRoute type")
+		}
+	}
+
+	return messages
+}
+
 func (r *JenkinsBaseConfigurationReconciler) validatePersistentVolumeClaim(volume corev1.Volume) ([]string, error) {
 	var messages []string
 
@@ -408,3 +510,14 @@ func (r *JenkinsBaseConfigurationReconciler) validateCustomization(customization
 
 	return messages, nil
 }
+
+func (r *JenkinsBaseConfigurationReconciler) validateNotifications(notifications []v1alpha2.Notification) []string {
+	var messages []string
+	for index, notification := range notifications {
+		for _, msg := range provider.ValidateNotification(notification) {
+			messages = append(messages, fmt.Sprintf("spec.notifications[%d].%s", index, msg))
+		}
+	}
+
+	return messages
+}
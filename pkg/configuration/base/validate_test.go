@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -962,3 +963,188 @@ func TestValidateJenkinsMasterContainerCommand(t *testing.T) {
 		assert.Len(t, got, 1)
 	})
 }
+
+func TestValidateNotifications(t *testing.T) {
+	baseReconcileLoop := New(configuration.Configuration{Jenkins: &v1alpha2.Jenkins{}}, client.JenkinsAPIConnectionSettings{})
+
+	t.Run("without template", func(t *testing.T) {
+		got := baseReconcileLoop.validateNotifications([]v1alpha2.Notification{{Name: "slack"}})
+
+		assert.Len(t, got, 0)
+	})
+	t.Run("valid template", func(t *testing.T) {
+		notifications := []v1alpha2.Notification{
+			{
+				Name: "slack",
+				Template: &v1alpha2.NotificationTemplate{
+					Title: "{{ .Jenkins.Name }}",
+					Body:  "{{ join .Messages \"\\n\" }}",
+				},
+			},
+		}
+
+		got := baseReconcileLoop.validateNotifications(notifications)
+
+		assert.Len(t, got, 0)
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		notifications := []v1alpha2.Notification{
+			{
+				Name: "slack",
+				Template: &v1alpha2.NotificationTemplate{
+					Title: "{{ .Jenkins.Name",
+					Body:  "{{ unknown }}",
+				},
+			},
+		}
+
+		got := baseReconcileLoop.validateNotifications(notifications)
+
+		assert.Len(t, got, 2)
+	})
+	t.Run("SMTP without recipients", func(t *testing.T) {
+		notifications := []v1alpha2.Notification{
+			{Name: "to", SMTP: &v1alpha2.SMTP{To: "jenkins@example.com"}},
+			{Name: "recipients", SMTP: &v1alpha2.SMTP{Recipients: []string{"jenkins@example.com"}}},
+			{Name: "cc only", SMTP: &v1alpha2.SMTP{CC: []string{"jenkins@example.com"}}},
+		}
+
+		got := baseReconcileLoop.validateNotifications(notifications)
+
+		assert.Equal(t, []string{"spec.notifications[2].smtp requires to or recipients"}, got)
+	})
+}
+
+func TestValidateStorage(t *testing.T) {
+	newReconciler := func(workloadType v1alpha2.WorkloadType, storage *v1alpha2.Storage) *JenkinsBaseConfigurationReconciler {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{WorkloadType: workloadType, Storage: storage}}}
+		return New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("no storage", func(t *testing.T) {
+		got := newReconciler(v1alpha2.PodWorkloadType, nil).validateStorage()
+
+		assert.Len(t, got, 0)
+	})
+	t.Run("valid storage", func(t *testing.T) {
+		got := newReconciler(v1alpha2.StatefulSetWorkloadType, &v1alpha2.Storage{Size: resource.MustParse("10Gi")}).validateStorage()
+
+		assert.Len(t, got, 0)
+	})
+	t.Run("storage without StatefulSet and size", func(t *testing.T) {
+		got := newReconciler(v1alpha2.DeploymentWorkloadType, &v1alpha2.Storage{}).validateStorage()
+
+		assert.Len(t, got, 2)
+	})
+}
+
+func TestValidateRestartPolicy(t *testing.T) {
+	newReconciler := func(restartPolicy *v1alpha2.RestartPolicy, windows ...v1alpha2.TimeWindow) *JenkinsBaseConfigurationReconciler {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{RestartPolicy: restartPolicy, MaintenanceWindows: windows}}
+		return New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("wait for idle", func(t *testing.T) {
+		got := newReconciler(&v1alpha2.RestartPolicy{Type: v1alpha2.WaitForIdleRestartPolicyType}).validateRestartPolicy()
+
+		assert.Len(t, got, 0)
+	})
+	t.Run("valid maintenance window", func(t *testing.T) {
+		got := newReconciler(&v1alpha2.RestartPolicy{Type: v1alpha2.MaintenanceWindowRestartPolicyType},
+			v1alpha2.TimeWindow{Days: []v1alpha2.Weekday{"Saturday"}, Start: "02:00", End: "04:00", TimeZone: "Europe/Warsaw"},
+		).validateRestartPolicy()
+
+		assert.Len(t, got, 0)
+	})
+	t.Run("missing maintenance window", func(t *testing.T) {
+		got := newReconciler(&v1alpha2.RestartPolicy{Type: v1alpha2.MaintenanceWindowRestartPolicyType}).validateRestartPolicy()
+
+		assert.Equal(t, []string{"spec.maintenanceWindows is required by the MaintenanceWindow restart policy"}, got)
+	})
+	t.Run("unrecognized type", func(t *testing.T) {
+		got := newReconciler(&v1alpha2.RestartPolicy{Type: "Never"}).validateRestartPolicy()
+
+		assert.Equal(t, []string{"unrecognized 'Never' spec.restartPolicy.type"}, got)
+	})
+}
+
+func TestValidateMaintenanceWindows(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{MaintenanceWindows: []v1alpha2.TimeWindow{
+		{Days: []v1alpha2.Weekday{"Saturday", "Sunday"}, Start: "22:00", End: "02:00", TimeZone: "Europe/Warsaw"},
+		{Start: "25:00", End: "02:00"},
+		{Days: []v1alpha2.Weekday{"Someday"}, Start: "22:00", End: "02:00"},
+		{Start: "22:00", End: "02:00", TimeZone: "Europe/Nowhere"},
+	}}}
+	baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+
+	got := baseReconcileLoop.validateMaintenanceWindows()
+
+	if assert.Len(t, got, 3) {
+		assert.Contains(t, got[0], "spec.maintenanceWindows[1] is invalid")
+		assert.Contains(t, got[1], "spec.maintenanceWindows[2] is invalid: unrecognized day 'Someday'")
+		assert.Contains(t, got[2], "spec.maintenanceWindows[3] is invalid")
+	}
+}
+
+func TestValidateIngress(t *testing.T) {
+	newReconciler := func(ingress *v1alpha2.Ingress) *JenkinsBaseConfigurationReconciler {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Ingress: ingress}}
+		return New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+	}
+
+	t.Run("not set", func(t *testing.T) {
+		assert.Empty(t, newReconciler(nil).validateIngress(false))
+	})
+	t.Run("valid Ingress", func(t *testing.T) {
+		got := newReconciler(&v1alpha2.Ingress{
+			Type:          v1alpha2.IngressIngressType,
+			Host:          "jenkins.example.com",
+			Path:          "/jenkins",
+			TLSSecretName: "jenkins-tls",
+			ClassName:     "nginx",
+		}).validateIngress(false)
+
+		assert.Empty(t, got)
+	})
+	t.Run("valid HTTPRoute", func(t *testing.T) {
+		got := newReconciler(&v1alpha2.Ingress{
+			Type:    v1alpha2.HTTPRouteIngressType,
+			Host:    "jenkins.example.com",
+			Gateway: &v1alpha2.GatewayReference{Name: "gateway", Namespace: "infra"},
+		}).validateIngress(true)
+
+		assert.Empty(t, got)
+	})
+	t.Run("HTTPRoute without the Gateway API", func(t *testing.T) {
+		got := newReconciler(&v1alpha2.Ingress{
+			Type:    v1alpha2.HTTPRouteIngressType,
+			Host:    "jenkins.example.com",
+			Gateway: &v1alpha2.GatewayReference{Name: "gateway"},
+		}).validateIngress(false)
+
+		assert.Equal(t, []string{"spec.ingress.type HTTPRoute requires the Gateway API gateway.networking.k8s.io/v1, which isn't available in the cluster"}, got)
+	})
+	t.Run("invalid host", func(t *testing.T) {
+		got := newReconciler(&v1alpha2.Ingress{
+			Type: v1alpha2.IngressIngressType,
+			Host: `jenkins.example.com"+x`,
+		}).validateIngress(false)
+
+		assert.Len(t, got, 1)
+		assert.Contains(t, got[0], "spec.ingress.host 'jenkins.example.com\"+x' is invalid")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		got := newReconciler(&v1alpha2.Ingress{
+			Type:      v1alpha2.HTTPRouteIngressType,
+			Path:      "jenkins",
+			ClassName: "nginx",
+		}).validateIngress(true)
+
+		assert.Equal(t, []string{
+			"spec.ingress.host must be set",
+			"spec.ingress.path must start with '/'",
+			"spec.ingress.gateway.name is required by the HTTPRoute type",
+			"spec.ingress.className can't be used with the HTTPRoute type",
+		}, got)
+	})
+}
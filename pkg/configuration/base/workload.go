@@ -0,0 +1,331 @@
+package base
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	stackerr "github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// isJenkinsMasterPodManagedByController returns true if the Jenkins master pod is managed by a Deployment or StatefulSet
+func isJenkinsMasterPodManagedByController(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Master.WorkloadType == v1alpha2.DeploymentWorkloadType ||
+		jenkins.Spec.Master.WorkloadType == v1alpha2.StatefulSetWorkloadType
+}
+
+func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMaster(meta metav1.ObjectMeta) (reconcile.Result, error) {
+	r.restartDeferred = false
+	if err := r.deleteStaleJenkinsMaster(); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	switch r.Configuration.Jenkins.Spec.Master.WorkloadType {
+	case v1alpha2.DeploymentWorkloadType:
+		return r.ensureJenkinsMasterWorkload(meta, r.ensureJenkinsDeployment)
+	case v1alpha2.StatefulSetWorkloadType:
+		return r.ensureJenkinsMasterWorkload(meta, r.ensureJenkinsStatefulSet)
+	default:
+		return r.ensureJenkinsMasterPod(meta)
+	}
+}
+
+// deleteStaleJenkinsMaster deletes the Jenkins master Pod, Deployment or StatefulSet left after
+// the spec.master.workloadType change
+func (r *JenkinsBaseConfigurationReconciler) deleteStaleJenkinsMaster() error {
+	jenkins := r.Configuration.Jenkins
+	var staleObjects []client.Object
+	if isJenkinsMasterPodManagedByController(jenkins) {
+		staleObjects = append(staleObjects, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: resources.GetJenkinsMasterPodName(jenkins)}})
+	}
+	if jenkins.Spec.Master.WorkloadType != v1alpha2.DeploymentWorkloadType {
+		staleObjects = append(staleObjects, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: resources.GetJenkinsDeploymentName(jenkins)}})
+	}
+	if jenkins.Spec.Master.WorkloadType != v1alpha2.StatefulSetWorkloadType {
+		staleObjects = append(staleObjects, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: resources.GetJenkinsStatefulSetName(jenkins)}})
+	}
+
+	for _, staleObject := range staleObjects {
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: staleObject.GetName()}, staleObject)
+		if err != nil && apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return stackerr.WithStack(err)
+		}
+		if !metav1.IsControlledBy(staleObject, jenkins) {
+			continue
+		}
+
+		r.logger.Info(fmt.Sprintf("Deleting %T %s/%s, spec.master.workloadType has changed to '%s'",
+			staleObject, staleObject.GetNamespace(), staleObject.GetName(), jenkins.Spec.Master.WorkloadType))
+		if err = r.Client.Delete(context.TODO(), staleObject); err != nil && !apierrors.IsNotFound(err) {
+			return stackerr.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterWorkload(meta metav1.ObjectMeta, ensureWorkload func(metav1.ObjectMeta) (reconcile.Result, error)) (reconcile.Result, error) {
+	userAndPasswordHash, err := r.calculateUserAndPasswordHash()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	result, err := ensureWorkload(meta)
+	if err != nil || result.Requeue {
+		return result, err
+	}
+
+	currentJenkinsMasterPod, err := r.Configuration.GetJenkinsMasterPod()
+	if err != nil && apierrors.IsNotFound(err) {
+		r.logger.V(log.VDebug).Info("Waiting for Jenkins master pod to be created")
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second * 5}, nil
+	} else if err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+
+	// the pod has been replaced by the Deployment or StatefulSet, configure it from the beginning
+	provisionStartTime := r.Configuration.Jenkins.Status.ProvisionStartTime
+	if provisionStartTime == nil || !provisionStartTime.Equal(&currentJenkinsMasterPod.CreationTimestamp) {
+		r.logger.Info(fmt.Sprintf("New Jenkins master pod %s/%s has been created", currentJenkinsMasterPod.Namespace, currentJenkinsMasterPod.Name))
+		metrics.StopRuntimeCollector(r.Configuration.Jenkins.Namespace, r.Configuration.Jenkins.Name)
+		backupAndRestore := backuprestore.New(r.Configuration, r.logger)
+		if backupAndRestore.IsBackupTriggerEnabled() {
+			backupAndRestore.StopBackupTrigger()
+		}
+		return reconcile.Result{Requeue: true}, r.resetStatus(currentJenkinsMasterPod.CreationTimestamp, userAndPasswordHash)
+	}
+
+	return r.checkJenkinsMasterPod(*currentJenkinsMasterPod, userAndPasswordHash)
+}
+
+func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsDeployment(meta metav1.ObjectMeta) (reconcile.Result, error) {
+	jenkinsDeployment := resources.NewJenkinsDeployment(meta, r.Configuration.Jenkins)
+	podTemplateHash, err := calculatePodTemplateHash(jenkinsDeployment.Spec.Template)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	currentJenkinsDeployment, err := r.GetJenkinsDeployment()
+	if err != nil && apierrors.IsNotFound(err) {
+		jenkinsDeployment.Annotations = map[string]string{constants.PodTemplateHashAnnotation: podTemplateHash}
+		r.notifyJenkinsMasterWorkloadCreation(jenkinsDeployment)
+		return reconcile.Result{Requeue: true}, stackerr.WithStack(r.CreateResource(jenkinsDeployment))
+	} else if err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+
+	deferred, err := r.isPodTemplateUpdateDeferred(currentJenkinsDeployment.Annotations[constants.PodTemplateHashAnnotation], podTemplateHash)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if deferred {
+		podTemplateHash = currentJenkinsDeployment.Annotations[constants.PodTemplateHashAnnotation]
+		jenkinsDeployment.Spec.Template = currentJenkinsDeployment.Spec.Template
+	}
+
+	if currentJenkinsDeployment.Annotations[constants.PodTemplateHashAnnotation] == podTemplateHash &&
+		currentJenkinsDeployment.Spec.Replicas != nil && *currentJenkinsDeployment.Spec.Replicas == 1 {
+		return reconcile.Result{}, nil
+	}
+
+	if currentJenkinsDeployment.Annotations == nil {
+		currentJenkinsDeployment.Annotations = map[string]string{}
+	}
+	currentJenkinsDeployment.Annotations[constants.PodTemplateHashAnnotation] = podTemplateHash
+	currentJenkinsDeployment.Labels = jenkinsDeployment.Labels
+	currentJenkinsDeployment.Spec.Replicas = jenkinsDeployment.Spec.Replicas
+	currentJenkinsDeployment.Spec.Strategy = jenkinsDeployment.Spec.Strategy
+	currentJenkinsDeployment.Spec.Template = jenkinsDeployment.Spec.Template
+	r.notifyJenkinsMasterWorkloadUpdate(currentJenkinsDeployment)
+	return reconcile.Result{Requeue: true}, stackerr.WithStack(r.UpdateResource(currentJenkinsDeployment))
+}
+
+func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsStatefulSet(meta metav1.ObjectMeta) (reconcile.Result, error) {
+	jenkinsStatefulSet := resources.NewJenkinsStatefulSet(meta, r.Configuration.Jenkins)
+	podTemplateHash, err := calculatePodTemplateHash(jenkinsStatefulSet.Spec.Template)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	currentJenkinsStatefulSet, err := r.GetJenkinsStatefulSet()
+	if err != nil && apierrors.IsNotFound(err) {
+		jenkinsStatefulSet.Annotations = map[string]string{constants.PodTemplateHashAnnotation: podTemplateHash}
+		r.notifyJenkinsMasterWorkloadCreation(jenkinsStatefulSet)
+		return reconcile.Result{Requeue: true}, stackerr.WithStack(r.CreateResource(jenkinsStatefulSet))
+	} else if err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+
+	if currentJenkinsStatefulSet.DeletionTimestamp != nil {
+		r.logger.V(log.VDebug).Info("Waiting for Jenkins StatefulSet to be deleted")
+		return reconcile.Result{Requeue: true, RequeueAfter: time.Second}, nil
+	}
+	if isJenkinsHomeStorageChanged(currentJenkinsStatefulSet.Spec.VolumeClaimTemplates, jenkinsStatefulSet.Spec.VolumeClaimTemplates) {
+		return r.updateJenkinsHomeStorage(currentJenkinsStatefulSet)
+	}
+
+	deferred, err := r.isPodTemplateUpdateDeferred(currentJenkinsStatefulSet.Annotations[constants.PodTemplateHashAnnotation], podTemplateHash)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if deferred {
+		podTemplateHash = currentJenkinsStatefulSet.Annotations[constants.PodTemplateHashAnnotation]
+		jenkinsStatefulSet.Spec.Template = currentJenkinsStatefulSet.Spec.Template
+	}
+
+	if currentJenkinsStatefulSet.Annotations[constants.PodTemplateHashAnnotation] == podTemplateHash &&
+		currentJenkinsStatefulSet.Spec.Replicas != nil && *currentJenkinsStatefulSet.Spec.Replicas == 1 {
+		return reconcile.Result{}, nil
+	}
+
+	if currentJenkinsStatefulSet.Annotations == nil {
+		currentJenkinsStatefulSet.Annotations = map[string]string{}
+	}
+	currentJenkinsStatefulSet.Annotations[constants.PodTemplateHashAnnotation] = podTemplateHash
+	currentJenkinsStatefulSet.Labels = jenkinsStatefulSet.Labels
+	currentJenkinsStatefulSet.Spec.Replicas = jenkinsStatefulSet.Spec.Replicas
+	currentJenkinsStatefulSet.Spec.UpdateStrategy = jenkinsStatefulSet.Spec.UpdateStrategy
+	currentJenkinsStatefulSet.Spec.Template = jenkinsStatefulSet.Spec.Template
+	r.notifyJenkinsMasterWorkloadUpdate(currentJenkinsStatefulSet)
+	return reconcile.Result{Requeue: true}, stackerr.WithStack(r.UpdateResource(currentJenkinsStatefulSet))
+}
+
+// updateJenkinsHomeStorage expands the Jenkins home persistent volume claim and recreates the StatefulSet, because
+// its volumeClaimTemplates are immutable. The StatefulSet is deleted with the orphan propagation policy, so the new one
+// adopts the running Jenkins master pod.
+func (r *JenkinsBaseConfigurationReconciler) updateJenkinsHomeStorage(currentJenkinsStatefulSet *appsv1.StatefulSet) (reconcile.Result, error) {
+	if storage := r.Configuration.Jenkins.Spec.Master.Storage; storage != nil {
+		if err := r.expandJenkinsHomePersistentVolumeClaim(*storage); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	r.logger.Info(fmt.Sprintf("Jenkins home storage has changed, recreating StatefulSet %s/%s",
+		currentJenkinsStatefulSet.Namespace, currentJenkinsStatefulSet.Name))
+	err := r.Client.Delete(context.TODO(), currentJenkinsStatefulSet, client.PropagationPolicy(metav1.DeletePropagationOrphan))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+
+	return reconcile.Result{Requeue: true}, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) expandJenkinsHomePersistentVolumeClaim(storage v1alpha2.Storage) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{
+		Namespace: r.Configuration.Jenkins.Namespace,
+		Name:      resources.GetJenkinsHomePersistentVolumeClaimName(r.Configuration.Jenkins),
+	}, pvc)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil // will be created by the StatefulSet
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	if storage.StorageClassName != nil && (pvc.Spec.StorageClassName == nil || *storage.StorageClassName != *pvc.Spec.StorageClassName) ||
+		!reflect.DeepEqual(storage.AccessModes, pvc.Spec.AccessModes) {
+		r.logger.V(log.VWarn).Info(fmt.Sprintf("Storage class and access modes of persistent volume claim %s/%s can't be changed, "+
+			"delete it together with the StatefulSet to apply them", pvc.Namespace, pvc.Name))
+	}
+
+	currentSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	switch storage.Size.Cmp(currentSize) {
+	case 0:
+		return nil
+	case -1:
+		r.logger.V(log.VWarn).Info(fmt.Sprintf("Persistent volume claim %s/%s can't shrink from '%s' to '%s', skipping",
+			pvc.Namespace, pvc.Name, currentSize.String(), storage.Size.String()))
+		return nil
+	}
+
+	r.logger.Info(fmt.Sprintf("Expanding persistent volume claim %s/%s from '%s' to '%s'",
+		pvc.Namespace, pvc.Name, currentSize.String(), storage.Size.String()))
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = storage.Size
+	return stackerr.WithStack(r.Client.Update(context.TODO(), pvc))
+}
+
+func isJenkinsHomeStorageChanged(current, expected []corev1.PersistentVolumeClaim) bool {
+	if len(current) != len(expected) {
+		return true
+	}
+	for i := range expected {
+		if current[i].Name != expected[i].Name ||
+			!reflect.DeepEqual(current[i].Spec.AccessModes, expected[i].Spec.AccessModes) ||
+			!reflect.DeepEqual(current[i].Spec.StorageClassName, expected[i].Spec.StorageClassName) ||
+			!current[i].Spec.Resources.Requests.Storage().Equal(*expected[i].Spec.Resources.Requests.Storage()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPodTemplateUpdateDeferred tells if the rolling update of the Jenkins master pod has to wait according to
+// spec.maintenanceWindows and spec.restartPolicy
+func (r *JenkinsBaseConfigurationReconciler) isPodTemplateUpdateDeferred(currentPodTemplateHash, podTemplateHash string) (bool, error) {
+	if currentPodTemplateHash == podTemplateHash {
+		return false, nil
+	}
+
+	restartReason := reason.NewPodRestart(reason.OperatorSource, []string{"Jenkins master pod template has changed"})
+	return r.deferRestart(restartReason)
+}
+
+func (r *JenkinsBaseConfigurationReconciler) notifyJenkinsMasterWorkloadCreation(workload client.Object) {
+	message := fmt.Sprintf("Creating a new Jenkins %s %s/%s", r.Configuration.Jenkins.Spec.Master.WorkloadType, workload.GetNamespace(), workload.GetName())
+	*r.Notifications <- event.Event{
+		Jenkins: *r.Configuration.Jenkins,
+		Phase:   event.PhaseBase,
+		Level:   v1alpha2.NotificationLevelInfo,
+		Reason:  reason.NewPodCreation(reason.OperatorSource, []string{message}),
+	}
+	r.logger.Info(message)
+}
+
+func (r *JenkinsBaseConfigurationReconciler) notifyJenkinsMasterWorkloadUpdate(workload client.Object) {
+	message := fmt.Sprintf("Jenkins master pod template has changed, rolling update of %s %s/%s",
+		r.Configuration.Jenkins.Spec.Master.WorkloadType, workload.GetNamespace(), workload.GetName())
+	restartReason := reason.NewPodRestart(reason.OperatorSource, []string{message})
+	*r.Notifications <- event.Event{
+		Jenkins: *r.Configuration.Jenkins,
+		Phase:   event.PhaseBase,
+		Level:   v1alpha2.NotificationLevelInfo,
+		Reason:  restartReason,
+	}
+	r.logger.Info(message)
+	metrics.RecordRestart(r.Configuration.Jenkins, reason.TypeName(restartReason))
+}
+
+func calculatePodTemplateHash(template corev1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", stackerr.WithStack(err)
+	}
+
+	hash := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString(hash[:]), nil
+}
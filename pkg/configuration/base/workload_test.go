@@ -0,0 +1,252 @@
+package base
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestEnsureJenkinsMaster(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	namespace := "default"
+	newJenkins := func(workloadType v1alpha2.WorkloadType) *v1alpha2.Jenkins {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: namespace},
+			Spec:       v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{WorkloadType: workloadType}},
+		}
+		jenkins.SetDefaults()
+		return jenkins
+	}
+	newReconciler := func(jenkins *v1alpha2.Jenkins) (*JenkinsBaseConfigurationReconciler, *fake.ClientBuilder) {
+		notifications := make(chan event.Event, 10)
+		credentialsSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.GetOperatorCredentialsSecretName(jenkins), Namespace: namespace},
+			Data: map[string][]byte{
+				resources.OperatorCredentialsSecretUserNameKey: []byte("user"),
+				resources.OperatorCredentialsSecretPasswordKey: []byte("password"),
+			},
+		}
+		return New(configuration.Configuration{
+			Jenkins:       jenkins,
+			Scheme:        scheme.Scheme,
+			Notifications: &notifications,
+		}, client.JenkinsAPIConnectionSettings{}), fake.NewClientBuilder().WithObjects(jenkins.DeepCopy(), credentialsSecret)
+	}
+	newPod := func(name string, owner metav1.Object, created time.Time) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				Labels:            map[string]string{constants.LabelAppKey: constants.LabelAppValue, constants.LabelJenkinsCRKey: "example"},
+				CreationTimestamp: metav1.NewTime(created.Truncate(time.Second)),
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		require.NoError(t, controllerutil.SetControllerReference(owner, pod, scheme.Scheme))
+		return pod
+	}
+
+	t.Run("Deployment rolling update", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.DeploymentWorkloadType)
+		reconciler, clientBuilder := newReconciler(jenkins)
+		stalePod := resources.NewJenkinsMasterPod(resources.NewResourceObjectMeta(jenkins), jenkins)
+		require.NoError(t, controllerutil.SetControllerReference(jenkins, stalePod, scheme.Scheme))
+		fakeClient := clientBuilder.WithObjects(stalePod).Build()
+		reconciler.Client = fakeClient
+		metaObject := resources.NewResourceObjectMeta(jenkins)
+
+		// creates Deployment and deletes pod created before workload type change
+		result, err := reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		deployment, err := reconciler.GetJenkinsDeployment()
+		require.NoError(t, err)
+		firstHash := deployment.Annotations[constants.PodTemplateHashAnnotation]
+		assert.NotEmpty(t, firstHash)
+		assert.Equal(t, resources.BuildResourceLabels(jenkins), deployment.Spec.Selector.MatchLabels)
+		err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: stalePod.Name}, &corev1.Pod{})
+		assert.True(t, apierrors.IsNotFound(err))
+
+		// waits for pod
+		result, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, result.RequeueAfter)
+
+		// new pod resets status
+		replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "jenkins-example-1", Namespace: namespace}}
+		oldPod := newPod("jenkins-example-1-old", replicaSet, time.Now().Add(-time.Hour))
+		pod := newPod("jenkins-example-1-new", replicaSet, time.Now())
+		require.NoError(t, fakeClient.Create(context.TODO(), oldPod))
+		require.NoError(t, fakeClient.Create(context.TODO(), pod))
+		reconciler.Configuration.Jenkins.Status.BaseConfigurationCompletedTime = &metav1.Time{Time: time.Now()}
+		result, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		currentPod, err := reconciler.GetJenkinsMasterPod()
+		require.NoError(t, err)
+		assert.Equal(t, pod.Name, currentPod.Name)
+		assert.True(t, pod.CreationTimestamp.Equal(jenkins.Status.ProvisionStartTime))
+		assert.Nil(t, jenkins.Status.BaseConfigurationCompletedTime)
+		assert.NotEmpty(t, jenkins.Status.UserAndPasswordHash)
+
+		// nothing to do
+		result, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+
+		// spec changes update the pod template
+		jenkins.Spec.Master.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+		result, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		deployment, err = reconciler.GetJenkinsDeployment()
+		require.NoError(t, err)
+		assert.NotEqual(t, firstHash, deployment.Annotations[constants.PodTemplateHashAnnotation])
+		assert.Equal(t, jenkins.Spec.Master.NodeSelector, deployment.Spec.Template.Spec.NodeSelector)
+	})
+	t.Run("Deployment rolling update deferred by restart policy", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.DeploymentWorkloadType)
+		jenkins.Spec.RestartPolicy = &v1alpha2.RestartPolicy{Type: v1alpha2.MaintenanceWindowRestartPolicyType}
+		now := time.Now().UTC()
+		jenkins.Spec.MaintenanceWindows = []v1alpha2.TimeWindow{{
+			Start: now.Add(2 * time.Hour).Format(timeOfDayLayout),
+			End:   now.Add(3 * time.Hour).Format(timeOfDayLayout),
+		}}
+		reconciler, clientBuilder := newReconciler(jenkins)
+		fakeClient := clientBuilder.Build()
+		reconciler.Client = fakeClient
+		metaObject := resources.NewResourceObjectMeta(jenkins)
+
+		_, err := reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		deployment, err := reconciler.GetJenkinsDeployment()
+		require.NoError(t, err)
+		firstHash := deployment.Annotations[constants.PodTemplateHashAnnotation]
+		replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "jenkins-example-1", Namespace: namespace}}
+		require.NoError(t, fakeClient.Create(context.TODO(), newPod("jenkins-example-1-pod", replicaSet, time.Now())))
+		_, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		jenkins.Status.BaseConfigurationCompletedTime = &metav1.Time{Time: time.Now()}
+
+		// outside the maintenance window
+		jenkins.Spec.Master.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+		result, err := reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+		deployment, err = reconciler.GetJenkinsDeployment()
+		require.NoError(t, err)
+		assert.Equal(t, firstHash, deployment.Annotations[constants.PodTemplateHashAnnotation])
+		assert.NotNil(t, jenkins.Status.PendingRestart)
+
+		assert.True(t, reconciler.restartDeferred)
+
+		// changes have been reverted, the pending restart is cancelled at the end of the reconcile loop
+		jenkins.Spec.Master.NodeSelector = nil
+		_, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.False(t, reconciler.restartDeferred)
+	})
+	t.Run("StatefulSet", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.StatefulSetWorkloadType)
+		reconciler, clientBuilder := newReconciler(jenkins)
+		fakeClient := clientBuilder.Build()
+		reconciler.Client = fakeClient
+		metaObject := resources.NewResourceObjectMeta(jenkins)
+
+		result, err := reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		statefulSet, err := reconciler.GetJenkinsStatefulSet()
+		require.NoError(t, err)
+		assert.Equal(t, resources.GetJenkinsHTTPServiceName(jenkins), statefulSet.Spec.ServiceName)
+
+		require.NoError(t, fakeClient.Create(context.TODO(), newPod(resources.GetJenkinsStatefulSetPodName(jenkins), statefulSet, time.Now())))
+		result, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		assert.NotNil(t, jenkins.Status.ProvisionStartTime)
+
+		// switching back to Pod deletes StatefulSet
+		jenkins.Spec.Master.WorkloadType = v1alpha2.PodWorkloadType
+		result, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		_, err = reconciler.GetJenkinsStatefulSet()
+		assert.True(t, apierrors.IsNotFound(err))
+		_, err = reconciler.GetJenkinsMasterPod()
+		assert.NoError(t, err)
+	})
+	t.Run("StatefulSet with storage expansion", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.StatefulSetWorkloadType)
+		jenkins.Spec.Master.Storage = &v1alpha2.Storage{Size: resource.MustParse("1Gi")}
+		jenkins.SetDefaults()
+		reconciler, clientBuilder := newReconciler(jenkins)
+		fakeClient := clientBuilder.Build()
+		reconciler.Client = fakeClient
+		metaObject := resources.NewResourceObjectMeta(jenkins)
+
+		_, err := reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		statefulSet, err := reconciler.GetJenkinsStatefulSet()
+		require.NoError(t, err)
+		if assert.Len(t, statefulSet.Spec.VolumeClaimTemplates, 1) {
+			claim := statefulSet.Spec.VolumeClaimTemplates[0]
+			assert.Equal(t, resources.JenkinsHomeVolumeName, claim.Name)
+			assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, claim.Spec.AccessModes)
+			assert.Equal(t, "1Gi", claim.Spec.Resources.Requests.Storage().String())
+		}
+		for _, volume := range statefulSet.Spec.Template.Spec.Volumes {
+			assert.NotEqual(t, resources.JenkinsHomeVolumeName, volume.Name)
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.GetJenkinsHomePersistentVolumeClaimName(jenkins), Namespace: namespace},
+			Spec:       statefulSet.Spec.VolumeClaimTemplates[0].Spec,
+		}
+		require.NoError(t, fakeClient.Create(context.TODO(), pvc))
+
+		// expands claim and recreates StatefulSet with the new volume claim template
+		jenkins.Spec.Master.Storage.Size = resource.MustParse("2Gi")
+		result, err := reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: pvc.Name}, pvc))
+		assert.Equal(t, "2Gi", pvc.Spec.Resources.Requests.Storage().String())
+		_, err = reconciler.GetJenkinsStatefulSet()
+		assert.True(t, apierrors.IsNotFound(err))
+
+		_, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		statefulSet, err = reconciler.GetJenkinsStatefulSet()
+		require.NoError(t, err)
+		assert.Equal(t, "2Gi", statefulSet.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String())
+
+		// claim doesn't shrink
+		jenkins.Spec.Master.Storage.Size = resource.MustParse("1Gi")
+		_, err = reconciler.ensureJenkinsMaster(metaObject)
+		require.NoError(t, err)
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: pvc.Name}, pvc))
+		assert.Equal(t, "2Gi", pvc.Spec.Resources.Requests.Storage().String())
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 
@@ -29,6 +30,7 @@ import (
 // Configuration holds required for Jenkins configuration.
 type Configuration struct {
 	Client                       client.Client
+	APIReader                    client.Reader
 	ClientSet                    kubernetes.Clientset
 	Notifications                *chan event.Event
 	Jenkins                      *v1alpha2.Jenkins
@@ -38,8 +40,20 @@ type Configuration struct {
 	KubernetesClusterDomain      string
 }
 
+// UpdateJenkinsStatus persists the status of the Jenkins CR. The client decodes the response into the updated object,
+// so the update is done on a copy to preserve the spec defaults set in memory by SetDefaults.
+func UpdateJenkinsStatus(k8sClient client.Client, jenkins *v1alpha2.Jenkins) error {
+	updated := jenkins.DeepCopy()
+	if err := k8sClient.Status().Update(context.TODO(), updated); err != nil {
+		return err // don't wrap error
+	}
+	jenkins.ObjectMeta = updated.ObjectMeta
+	jenkins.Status = updated.Status
+	return nil
+}
+
 // RestartJenkinsMasterPod terminate Jenkins master pod and notifies about it.
-func (c *Configuration) RestartJenkinsMasterPod(reason reason.Reason) error {
+func (c *Configuration) RestartJenkinsMasterPod(restartReason reason.Reason) error {
 	currentJenkinsMasterPod, err := c.GetJenkinsMasterPod()
 	if err != nil {
 		return err
@@ -53,15 +67,28 @@ func (c *Configuration) RestartJenkinsMasterPod(reason reason.Reason) error {
 		Jenkins: *c.Jenkins,
 		Phase:   event.PhaseBase,
 		Level:   v1alpha2.NotificationLevelInfo,
-		Reason:  reason,
+		Reason:  restartReason,
 	}
 
-	return stackerr.WithStack(c.Client.Delete(context.TODO(), currentJenkinsMasterPod))
+	err = c.Client.Delete(context.TODO(), currentJenkinsMasterPod)
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+	metrics.RecordRestart(c.Jenkins, reason.TypeName(restartReason))
+
+	return nil
 }
 
-// GetJenkinsMasterPod gets the jenkins master pod.
+// GetJenkinsMasterPod gets the jenkins master pod, the newest one when the Deployment is rolling out.
 func (c *Configuration) GetJenkinsMasterPod() (*corev1.Pod, error) {
 	jenkinsMasterPodName := resources.GetJenkinsMasterPodName(c.Jenkins)
+	switch c.Jenkins.Spec.Master.WorkloadType {
+	case v1alpha2.DeploymentWorkloadType:
+		return c.getJenkinsDeploymentPod()
+	case v1alpha2.StatefulSetWorkloadType:
+		jenkinsMasterPodName = resources.GetJenkinsStatefulSetPodName(c.Jenkins)
+	}
+
 	currentJenkinsMasterPod := &corev1.Pod{}
 	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: jenkinsMasterPodName, Namespace: c.Jenkins.Namespace}, currentJenkinsMasterPod)
 	if err != nil {
@@ -70,17 +97,53 @@ func (c *Configuration) GetJenkinsMasterPod() (*corev1.Pod, error) {
 	return currentJenkinsMasterPod, nil
 }
 
-// GetJenkinsMasterPod gets the jenkins master pod.
+func (c *Configuration) getJenkinsDeploymentPod() (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	err := c.Client.List(context.TODO(), pods, client.InNamespace(c.Jenkins.Namespace), client.MatchingLabels(resources.BuildResourceLabels(c.Jenkins)))
+	if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	var currentJenkinsMasterPod *corev1.Pod
+	for i, pod := range pods.Items {
+		if owner := metav1.GetControllerOf(&pod); owner == nil || owner.Kind != "ReplicaSet" {
+			continue
+		}
+		if currentJenkinsMasterPod == nil ||
+			// prefer running pods over terminating ones, then the newest one
+			c.IsJenkinsTerminating(*currentJenkinsMasterPod) && !c.IsJenkinsTerminating(pod) ||
+			c.IsJenkinsTerminating(*currentJenkinsMasterPod) == c.IsJenkinsTerminating(pod) && currentJenkinsMasterPod.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			currentJenkinsMasterPod = &pods.Items[i]
+		}
+	}
+	if currentJenkinsMasterPod == nil {
+		return nil, errors.NewNotFound(corev1.Resource("pods"), resources.GetJenkinsDeploymentName(c.Jenkins))
+	}
+	return currentJenkinsMasterPod, nil
+}
+
+// GetJenkinsDeployment gets the jenkins master Deployment.
 func (c *Configuration) GetJenkinsDeployment() (*appsv1.Deployment, error) {
 	jenkinsDeploymentName := resources.GetJenkinsDeploymentName(c.Jenkins)
 	currentJenkinsDeployment := &appsv1.Deployment{}
 	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: jenkinsDeploymentName, Namespace: c.Jenkins.Namespace}, currentJenkinsDeployment)
 	if err != nil {
-		return nil, stackerr.WithStack(err)
+		return nil, err // don't wrap error
 	}
 	return currentJenkinsDeployment, nil
 }
 
+// GetJenkinsStatefulSet gets the jenkins master StatefulSet.
+func (c *Configuration) GetJenkinsStatefulSet() (*appsv1.StatefulSet, error) {
+	jenkinsStatefulSetName := resources.GetJenkinsStatefulSetName(c.Jenkins)
+	currentJenkinsStatefulSet := &appsv1.StatefulSet{}
+	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: jenkinsStatefulSetName, Namespace: c.Jenkins.Namespace}, currentJenkinsStatefulSet)
+	if err != nil {
+		return nil, err // don't wrap error
+	}
+	return currentJenkinsStatefulSet, nil
+}
+
 // IsJenkinsTerminating returns true if the Jenkins pod is terminating.
 func (c *Configuration) IsJenkinsTerminating(pod corev1.Pod) bool {
 	return pod.ObjectMeta.DeletionTimestamp != nil
@@ -214,8 +277,11 @@ func (c *Configuration) GetJenkinsClientFromServiceAccount() (jenkinsclient.Jenk
 		return nil, err
 	}
 
-	podName := resources.GetJenkinsMasterPodName(c.Jenkins)
-	token, _, err := c.Exec(podName, resources.JenkinsMasterContainerName, []string{"cat", "/var/run/secrets/kubernetes.io/serviceaccount/token"})
+	currentJenkinsMasterPod, err := c.GetJenkinsMasterPod()
+	if err != nil {
+		return nil, err
+	}
+	token, _, err := c.Exec(currentJenkinsMasterPod.Name, resources.JenkinsMasterContainerName, []string{"cat", "/var/run/secrets/kubernetes.io/serviceaccount/token"})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,74 @@
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// statusSubresourceClient updates only the status like the API server does, the spec of the request is ignored
+// and the response contains the persisted spec
+type statusSubresourceClient struct {
+	client.Client
+}
+
+func (c statusSubresourceClient) Status() client.StatusWriter {
+	return statusSubresourceWriter(c)
+}
+
+type statusSubresourceWriter struct {
+	client.Client
+}
+
+func (w statusSubresourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	jenkins := obj.(*v1alpha2.Jenkins)
+	persisted := &v1alpha2.Jenkins{}
+	if err := w.Client.Get(ctx, types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, persisted); err != nil {
+		return err
+	}
+	persisted.ResourceVersion = jenkins.ResourceVersion
+	persisted.Status = jenkins.Status
+	if err := w.Client.Update(ctx, persisted); err != nil {
+		return err
+	}
+	persisted.DeepCopyInto(jenkins)
+	return nil
+}
+
+func (w statusSubresourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return w.Client.Status().Patch(ctx, obj, patch, opts...)
+}
+
+func TestUpdateJenkinsStatus(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	persisted := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+	k8sClient := statusSubresourceClient{fake.NewClientBuilder().WithObjects(persisted).Build()}
+	jenkins := &v1alpha2.Jenkins{}
+	require.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Name: "example", Namespace: "default"}, jenkins))
+	jenkins.SetDefaults()
+	defaulted := jenkins.Spec.DeepCopy()
+
+	jenkins.Status.OperatorVersion = "v1"
+	require.NoError(t, UpdateJenkinsStatus(k8sClient, jenkins))
+
+	assert.Equal(t, *defaulted, jenkins.Spec)
+	assert.Equal(t, "v1", jenkins.Status.OperatorVersion)
+
+	// the resource version has been updated, the next update doesn't conflict
+	jenkins.Status.OperatorVersion = "v2"
+	require.NoError(t, UpdateJenkinsStatus(k8sClient, jenkins))
+
+	require.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Name: "example", Namespace: "default"}, persisted))
+	assert.Equal(t, "v2", persisted.Status.OperatorVersion)
+	assert.Empty(t, persisted.Spec.Master.Containers)
+	assert.Equal(t, *defaulted, jenkins.Spec)
+}
@@ -233,7 +233,7 @@ func (s *seedJobs) EnsureSeedJobs(jenkins *v1alpha2.Jenkins) (done bool, err err
 	seedJobIDs := s.getAllSeedJobIDs(*jenkins)
 	if !reflect.DeepEqual(seedJobIDs, jenkins.Status.CreatedSeedJobs) {
 		jenkins.Status.CreatedSeedJobs = seedJobIDs
-		return false, stackerr.WithStack(s.Client.Status().Update(context.TODO(), jenkins))
+		return false, stackerr.WithStack(configuration.UpdateJenkinsStatus(s.Client, jenkins))
 	}
 
 	return true, nil
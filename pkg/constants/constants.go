@@ -15,4 +15,19 @@ const (
 	DefaultSlavePortInt32 = int32(50000)
 	// JavaOpsVariableName is the name of environment variable which consists Jenkins Java options
 	JavaOpsVariableName = "JAVA_OPTS"
+	// JenkinsMasterContainerName is the Jenkins master container name in pod
+	JenkinsMasterContainerName = "jenkins-master"
+	// JenkinsScriptsVolumePath is a path where are scripts used to configure Jenkins
+	JenkinsScriptsVolumePath = "/var/jenkins/scripts"
+	// InitScriptName is the init script name which configures init.groovy.d, scripts and install plugins
+	InitScriptName = "init.sh"
+	// JenkinsFinalizer is the finalizer which cleans up resources not garbage collected after Jenkins CR deletion
+	JenkinsFinalizer = "jenkins.io/finalizer"
+	// ResetReconcileErrorsAnnotation is the Jenkins CR annotation which resets the reconcile loop errors counter
+	ResetReconcileErrorsAnnotation = "jenkins.io/reset-reconcile-errors"
+	// PodTemplateHashAnnotation is the Deployment and StatefulSet annotation with the hash of the Jenkins master pod template
+	PodTemplateHashAnnotation = "jenkins.io/pod-template-hash"
+	// MaintenanceWindowOverrideAnnotation is the Jenkins CR annotation which allows disruptive actions outside
+	// spec.maintenanceWindows when it's set to "true"
+	MaintenanceWindowOverrideAnnotation = "jenkins.io/maintenance-window-override"
 )
@@ -10,7 +10,9 @@ import (
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -48,6 +50,7 @@ func (g *Groovy) EnsureSingle(source, name, hash, groovyScript string) (requeue
 	}
 
 	logs, err := g.jenkinsClient.ExecuteScript(groovyScript)
+	metrics.RecordGroovyScriptExecution(g.jenkins, g.configurationType, err)
 	if err != nil {
 		if groovyErr, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed); ok {
 			groovyErr.ConfigurationType = g.configurationType
@@ -77,7 +80,7 @@ func (g *Groovy) EnsureSingle(source, name, hash, groovyScript string) (requeue
 
 	g.jenkins.Status.AppliedGroovyScripts = appliedGroovyScripts
 
-	return true, g.k8sClient.Status().Update(context.TODO(), g.jenkins)
+	return true, configuration.UpdateJenkinsStatus(g.k8sClient, g.jenkins)
 }
 
 // WaitForSecretSynchronization runs groovy script which waits to synchronize secrets in pod by k8s
@@ -0,0 +1,185 @@
+// Package metrics contains Prometheus metrics of the operator, they are exposed by the manager metrics endpoint.
+// Notification metrics are defined in the notifications package.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "jenkins_operator_reconcile_phase_duration_seconds",
+			Help:    "Duration of the base and user configuration phases of a single reconcile loop.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 15),
+		},
+		[]string{"namespace", "name", "phase"},
+	)
+
+	restartsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jenkins_operator_master_pod_restarts_total",
+			Help: "Number of Jenkins master pod restarts made by the operator by reason.",
+		},
+		[]string{"namespace", "name", "reason"},
+	)
+
+	backupDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "jenkins_operator_backup_duration_seconds",
+			Help:    "Duration of the backup action by result: success or failure.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		},
+		[]string{"namespace", "name", "result"},
+	)
+
+	restoreDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "jenkins_operator_restore_duration_seconds",
+			Help:    "Duration of the restore action by result: success or failure.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		},
+		[]string{"namespace", "name", "result"},
+	)
+
+	lastBackupTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jenkins_operator_last_successful_backup_timestamp_seconds",
+			Help: "Unix timestamp of the last successful backup.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	groovyScriptExecutionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jenkins_operator_groovy_script_executions_total",
+			Help: "Number of groovy script executions by configuration type.",
+		},
+		[]string{"namespace", "name", "configuration_type"},
+	)
+
+	groovyScriptFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jenkins_operator_groovy_script_failures_total",
+			Help: "Number of failed groovy script executions by configuration type.",
+		},
+		[]string{"namespace", "name", "configuration_type"},
+	)
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(
+		reconcileDuration,
+		restartsTotal,
+		backupDuration,
+		restoreDuration,
+		lastBackupTimestamp,
+		groovyScriptExecutionsTotal,
+		groovyScriptFailuresTotal,
+	)
+}
+
+// labeledVec is the metric vector with the namespace, name and one more label
+type labeledVec interface {
+	DeleteLabelValues(labelValues ...string) bool
+}
+
+type crSeries struct {
+	vec   labeledVec
+	label string
+}
+
+// crSeriesTracker remembers the series of each Jenkins CR, so they can be removed when the CR is deleted
+type crSeriesTracker struct {
+	mutex  sync.Mutex
+	series map[string]map[crSeries]struct{}
+}
+
+var trackedSeries = crSeriesTracker{series: map[string]map[crSeries]struct{}{}}
+
+func (t *crSeriesTracker) key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func (t *crSeriesTracker) track(jenkins *v1alpha2.Jenkins, vec labeledVec, label string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := t.key(jenkins.Namespace, jenkins.Name)
+	if t.series[key] == nil {
+		t.series[key] = map[crSeries]struct{}{}
+	}
+	t.series[key][crSeries{vec: vec, label: label}] = struct{}{}
+}
+
+func (t *crSeriesTracker) delete(namespace, name string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := t.key(namespace, name)
+	for series := range t.series[key] {
+		series.vec.DeleteLabelValues(namespace, name, series.label)
+	}
+	delete(t.series, key)
+}
+
+// DeleteCRMetrics removes the metrics of the deleted Jenkins CR, runtime metrics are removed by StopRuntimeCollector.
+func DeleteCRMetrics(namespace, name string) {
+	trackedSeries.delete(namespace, name)
+	lastBackupTimestamp.DeleteLabelValues(namespace, name)
+}
+
+func result(err error) string {
+	if err != nil {
+		return resultFailure
+	}
+	return resultSuccess
+}
+
+// ObserveReconcilePhase records the duration of the configuration phase which started at start.
+func ObserveReconcilePhase(jenkins *v1alpha2.Jenkins, phase string, start time.Time) {
+	reconcileDuration.WithLabelValues(jenkins.Namespace, jenkins.Name, phase).Observe(time.Since(start).Seconds())
+	trackedSeries.track(jenkins, reconcileDuration, phase)
+}
+
+// RecordRestart counts the Jenkins master pod restart.
+func RecordRestart(jenkins *v1alpha2.Jenkins, reason string) {
+	restartsTotal.WithLabelValues(jenkins.Namespace, jenkins.Name, reason).Inc()
+	trackedSeries.track(jenkins, restartsTotal, reason)
+}
+
+// ObserveBackup records the duration and the result of the backup which started at start.
+func ObserveBackup(jenkins *v1alpha2.Jenkins, start time.Time, err error) {
+	backupDuration.WithLabelValues(jenkins.Namespace, jenkins.Name, result(err)).Observe(time.Since(start).Seconds())
+	trackedSeries.track(jenkins, backupDuration, result(err))
+	if err == nil {
+		lastBackupTimestamp.WithLabelValues(jenkins.Namespace, jenkins.Name).SetToCurrentTime()
+	}
+}
+
+// ObserveRestore records the duration and the result of the restore which started at start.
+func ObserveRestore(jenkins *v1alpha2.Jenkins, start time.Time, err error) {
+	restoreDuration.WithLabelValues(jenkins.Namespace, jenkins.Name, result(err)).Observe(time.Since(start).Seconds())
+	trackedSeries.track(jenkins, restoreDuration, result(err))
+}
+
+// RecordGroovyScriptExecution counts the groovy script execution and its failure.
+func RecordGroovyScriptExecution(jenkins *v1alpha2.Jenkins, configurationType string, err error) {
+	groovyScriptExecutionsTotal.WithLabelValues(jenkins.Namespace, jenkins.Name, configurationType).Inc()
+	trackedSeries.track(jenkins, groovyScriptExecutionsTotal, configurationType)
+	if err != nil {
+		groovyScriptFailuresTotal.WithLabelValues(jenkins.Namespace, jenkins.Name, configurationType).Inc()
+		trackedSeries.track(jenkins, groovyScriptFailuresTotal, configurationType)
+	}
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var jenkins = &v1alpha2.Jenkins{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "jenkins",
+		Namespace: "default",
+	},
+}
+
+func TestObserveReconcilePhase(t *testing.T) {
+	ObserveReconcilePhase(jenkins, "base", time.Now())
+	ObserveReconcilePhase(jenkins, "user", time.Now())
+	ObserveReconcilePhase(jenkins, "user", time.Now())
+
+	assert.Equal(t, 2, testutil.CollectAndCount(reconcileDuration))
+}
+
+func TestRecordRestart(t *testing.T) {
+	RecordRestart(jenkins, "PodRestart")
+	RecordRestart(jenkins, "PodRestart")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(restartsTotal.WithLabelValues("default", "jenkins", "PodRestart")))
+}
+
+func TestObserveBackup(t *testing.T) {
+	t.Run("failure", func(t *testing.T) {
+		ObserveBackup(jenkins, time.Now(), errors.New("backup failed"))
+
+		assert.Equal(t, 1, testutil.CollectAndCount(backupDuration))
+		assert.Equal(t, 0, testutil.CollectAndCount(lastBackupTimestamp))
+	})
+	t.Run("success", func(t *testing.T) {
+		ObserveBackup(jenkins, time.Now(), nil)
+
+		assert.Equal(t, 2, testutil.CollectAndCount(backupDuration))
+		assert.InDelta(t, float64(time.Now().Unix()), testutil.ToFloat64(lastBackupTimestamp.WithLabelValues("default", "jenkins")), 5)
+	})
+}
+
+func TestRecordGroovyScriptExecution(t *testing.T) {
+	RecordGroovyScriptExecution(jenkins, "user-groovy", nil)
+	RecordGroovyScriptExecution(jenkins, "user-groovy", errors.New("script failed"))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(groovyScriptExecutionsTotal.WithLabelValues("default", "jenkins", "user-groovy")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(groovyScriptFailuresTotal.WithLabelValues("default", "jenkins", "user-groovy")))
+}
+
+func TestDeleteCRMetrics(t *testing.T) {
+	deleted := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "default"}}
+	ObserveReconcilePhase(deleted, "base", time.Now())
+	RecordRestart(deleted, "PodRestart")
+	ObserveBackup(deleted, time.Now(), nil)
+	ObserveRestore(deleted, time.Now(), errors.New("restore failed"))
+	RecordGroovyScriptExecution(deleted, "user-groovy", errors.New("script failed"))
+	RecordRestart(jenkins, "PodRestart")
+
+	DeleteCRMetrics("default", "deleted")
+
+	assert.False(t, reconcileDuration.DeleteLabelValues("default", "deleted", "base"))
+	assert.False(t, restartsTotal.DeleteLabelValues("default", "deleted", "PodRestart"))
+	assert.False(t, backupDuration.DeleteLabelValues("default", "deleted", resultSuccess))
+	assert.False(t, restoreDuration.DeleteLabelValues("default", "deleted", resultFailure))
+	assert.False(t, lastBackupTimestamp.DeleteLabelValues("default", "deleted"))
+	assert.False(t, groovyScriptExecutionsTotal.DeleteLabelValues("default", "deleted", "user-groovy"))
+	assert.False(t, groovyScriptFailuresTotal.DeleteLabelValues("default", "deleted", "user-groovy"))
+	// other CRs are untouched
+	assert.NotZero(t, testutil.ToFloat64(restartsTotal.WithLabelValues("default", "jenkins", "PodRestart")))
+}
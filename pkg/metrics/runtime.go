@@ -0,0 +1,270 @@
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	defaultRuntimeMetricsInterval = 60 * time.Second
+
+	stateBusy    = "busy"
+	stateIdle    = "idle"
+	stateOnline  = "online"
+	stateOffline = "offline"
+)
+
+var (
+	jenkinsQueueItems = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jenkins_operator_jenkins_queue_items",
+			Help: "Number of items in the Jenkins build queue.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	jenkinsExecutors = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jenkins_operator_jenkins_executors",
+			Help: "Number of Jenkins executors of online nodes by state: busy or idle.",
+		},
+		[]string{"namespace", "name", "state"},
+	)
+
+	jenkinsNodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jenkins_operator_jenkins_nodes",
+			Help: "Number of Jenkins nodes by state: online or offline.",
+		},
+		[]string{"namespace", "name", "state"},
+	)
+
+	jenkinsJobs = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "jenkins_operator_jenkins_jobs",
+			Help: "Number of top level Jenkins jobs and folders.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	runtimeMetricsErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jenkins_operator_jenkins_runtime_metrics_errors_total",
+			Help: "Number of failed collections of Jenkins runtime metrics.",
+		},
+		[]string{"namespace", "name"},
+	)
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(
+		jenkinsQueueItems,
+		jenkinsExecutors,
+		jenkinsNodes,
+		jenkinsJobs,
+		runtimeMetricsErrorsTotal,
+	)
+}
+
+type runtimeCollector struct {
+	mutex         sync.Mutex
+	jenkinsClient jenkinsclient.Jenkins
+	interval      time.Duration
+	ticker        *time.Ticker
+	done          chan struct{}
+	// stopped is guarded by mutex, so the metrics aren't set again after the collector has been stopped
+	stopped bool
+}
+
+func (c *runtimeCollector) client() jenkinsclient.Jenkins {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.jenkinsClient
+}
+
+func (c *runtimeCollector) setClient(jenkinsClient jenkinsclient.Jenkins) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.jenkinsClient = jenkinsClient
+}
+
+func (c *runtimeCollector) run(namespace, name string) {
+	logger := log.Log.WithValues("cr", name)
+	for {
+		if err := c.collect(namespace, name); err != nil {
+			logger.V(log.VWarn).Info(fmt.Sprintf("Failed to collect Jenkins runtime metrics: %s", err))
+		}
+
+		select {
+		case <-c.done:
+			return
+		case <-c.ticker.C:
+		}
+	}
+}
+
+// collect fetches the runtime metrics from Jenkins and sets them unless the collector has been stopped meanwhile
+func (c *runtimeCollector) collect(namespace, name string) error {
+	metrics, err := fetchRuntimeMetrics(c.client())
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.stopped {
+		return nil
+	}
+	if err != nil {
+		runtimeMetricsErrorsTotal.WithLabelValues(namespace, name).Inc()
+		return err
+	}
+	metrics.set(namespace, name)
+	return nil
+}
+
+// stop doesn't wait for the in-flight collection, which may take long when Jenkins doesn't respond
+func (c *runtimeCollector) stop() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stopped = true
+	c.ticker.Stop()
+	close(c.done)
+}
+
+type runtimeCollectors struct {
+	mutex      sync.Mutex
+	collectors map[string]*runtimeCollector
+}
+
+func (r *runtimeCollectors) key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+var collectors = runtimeCollectors{collectors: make(map[string]*runtimeCollector)}
+
+func runtimeMetricsInterval(jenkins *v1alpha2.Jenkins) time.Duration {
+	if jenkins.Spec.RuntimeMetrics.IntervalSeconds <= 0 {
+		return defaultRuntimeMetricsInterval
+	}
+	return time.Duration(jenkins.Spec.RuntimeMetrics.IntervalSeconds) * time.Second
+}
+
+// EnsureRuntimeCollector starts, updates or stops the Jenkins runtime metrics collector according to spec.runtimeMetrics.
+func EnsureRuntimeCollector(jenkins *v1alpha2.Jenkins, jenkinsClient jenkinsclient.Jenkins) {
+	if jenkins.Spec.RuntimeMetrics == nil {
+		StopRuntimeCollector(jenkins.Namespace, jenkins.Name)
+		return
+	}
+
+	interval := runtimeMetricsInterval(jenkins)
+	key := collectors.key(jenkins.Namespace, jenkins.Name)
+
+	collectors.mutex.Lock()
+	defer collectors.mutex.Unlock()
+
+	collector, found := collectors.collectors[key]
+	if found && collector.interval == interval {
+		collector.setClient(jenkinsClient) // the token may change after the Jenkins master pod restart
+		return
+	}
+	if found {
+		collector.stop()
+	}
+
+	log.Log.WithValues("cr", jenkins.Name).Info(fmt.Sprintf("Starting Jenkins runtime metrics collector with interval %s", interval))
+	collector = &runtimeCollector{
+		jenkinsClient: jenkinsClient,
+		interval:      interval,
+		ticker:        time.NewTicker(interval),
+		done:          make(chan struct{}),
+	}
+	collectors.collectors[key] = collector
+	go collector.run(jenkins.Namespace, jenkins.Name)
+}
+
+// StopRuntimeCollector stops the Jenkins runtime metrics collector and removes its metrics.
+func StopRuntimeCollector(namespace, name string) {
+	key := collectors.key(namespace, name)
+
+	collectors.mutex.Lock()
+	defer collectors.mutex.Unlock()
+
+	collector, found := collectors.collectors[key]
+	if !found {
+		return
+	}
+	log.Log.WithValues("cr", name).Info("Stopping Jenkins runtime metrics collector")
+	collector.stop()
+	delete(collectors.collectors, key)
+
+	jenkinsQueueItems.DeleteLabelValues(namespace, name)
+	jenkinsExecutors.DeleteLabelValues(namespace, name, stateBusy)
+	jenkinsExecutors.DeleteLabelValues(namespace, name, stateIdle)
+	jenkinsNodes.DeleteLabelValues(namespace, name, stateOnline)
+	jenkinsNodes.DeleteLabelValues(namespace, name, stateOffline)
+	jenkinsJobs.DeleteLabelValues(namespace, name)
+	runtimeMetricsErrorsTotal.DeleteLabelValues(namespace, name)
+}
+
+type runtimeMetrics struct {
+	queueItems int
+	online     int
+	offline    int
+	busy       int
+	idle       int
+	jobs       int
+}
+
+func fetchRuntimeMetrics(jenkinsClient jenkinsclient.Jenkins) (*runtimeMetrics, error) {
+	metrics := &runtimeMetrics{}
+	queue, err := jenkinsClient.GetQueue()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if queue != nil && queue.Raw != nil {
+		metrics.queueItems = len(queue.Raw.Items)
+	}
+
+	nodes, err := jenkinsClient.GetAllNodes()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, node := range nodes {
+		if node.Raw.Offline {
+			metrics.offline++
+			continue
+		}
+		metrics.online++
+		for _, executor := range node.Raw.Executors {
+			if len(executor.CurrentExecutable.URL) > 0 {
+				metrics.busy++
+			} else {
+				metrics.idle++
+			}
+		}
+	}
+
+	jobs, err := jenkinsClient.GetAllJobNames()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	metrics.jobs = len(jobs)
+
+	return metrics, nil
+}
+
+func (m *runtimeMetrics) set(namespace, name string) {
+	jenkinsQueueItems.WithLabelValues(namespace, name).Set(float64(m.queueItems))
+	jenkinsNodes.WithLabelValues(namespace, name, stateOnline).Set(float64(m.online))
+	jenkinsNodes.WithLabelValues(namespace, name, stateOffline).Set(float64(m.offline))
+	jenkinsExecutors.WithLabelValues(namespace, name, stateBusy).Set(float64(m.busy))
+	jenkinsExecutors.WithLabelValues(namespace, name, stateIdle).Set(float64(m.idle))
+	jenkinsJobs.WithLabelValues(namespace, name).Set(float64(m.jobs))
+}
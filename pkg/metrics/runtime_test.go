@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newQueue(t *testing.T, raw string) *gojenkins.Queue {
+	queue := &gojenkins.Queue{}
+	require.NoError(t, json.Unmarshal([]byte(raw), queue))
+	return queue
+}
+
+func newNode(t *testing.T, raw string) *gojenkins.Node {
+	node := &gojenkins.Node{Raw: &gojenkins.NodeResponse{}}
+	require.NoError(t, json.Unmarshal([]byte(raw), node.Raw))
+	return node
+}
+
+func TestCollectRuntimeMetrics(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetQueue().Return(newQueue(t, `{"Raw": {"Items": [{"id": 1}, {"id": 2}, {"id": 3}]}}`), nil)
+		jenkinsClient.EXPECT().GetAllNodes().Return([]*gojenkins.Node{
+			newNode(t, `{"displayName": "master", "offline": false, "executors": [{"currentExecutable": {"url": "http://jenkins/job/a/1/"}}, {}]}`),
+			newNode(t, `{"displayName": "agent-1", "offline": false, "executors": [{}]}`),
+			newNode(t, `{"displayName": "agent-2", "offline": true, "executors": [{}, {}]}`),
+		}, nil)
+		jenkinsClient.EXPECT().GetAllJobNames().Return([]gojenkins.InnerJob{{Name: "a"}, {Name: "b"}}, nil)
+
+		err := (&runtimeCollector{jenkinsClient: jenkinsClient}).collect("default", "happy")
+
+		assert.NoError(t, err)
+		assert.Equal(t, float64(3), testutil.ToFloat64(jenkinsQueueItems.WithLabelValues("default", "happy")))
+		assert.Equal(t, float64(2), testutil.ToFloat64(jenkinsNodes.WithLabelValues("default", "happy", stateOnline)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(jenkinsNodes.WithLabelValues("default", "happy", stateOffline)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(jenkinsExecutors.WithLabelValues("default", "happy", stateBusy)))
+		assert.Equal(t, float64(2), testutil.ToFloat64(jenkinsExecutors.WithLabelValues("default", "happy", stateIdle)))
+		assert.Equal(t, float64(2), testutil.ToFloat64(jenkinsJobs.WithLabelValues("default", "happy")))
+		assert.Equal(t, float64(0), testutil.ToFloat64(runtimeMetricsErrorsTotal.WithLabelValues("default", "happy")))
+	})
+	t.Run("Jenkins API error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetQueue().Return(nil, errors.New("connection refused"))
+
+		err := (&runtimeCollector{jenkinsClient: jenkinsClient}).collect("default", "error")
+
+		assert.Error(t, err)
+		assert.Equal(t, float64(1), testutil.ToFloat64(runtimeMetricsErrorsTotal.WithLabelValues("default", "error")))
+	})
+	t.Run("stopped during the collection", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		collecting := make(chan struct{})
+		unblock := make(chan struct{})
+		jenkinsClient.EXPECT().GetQueue().DoAndReturn(func() (*gojenkins.Queue, error) {
+			close(collecting)
+			<-unblock
+			return newQueue(t, `{"Raw": {"Items": []}}`), nil
+		})
+		jenkinsClient.EXPECT().GetAllNodes().Return(nil, nil)
+		jenkinsClient.EXPECT().GetAllJobNames().Return(nil, nil)
+		collector := &runtimeCollector{jenkinsClient: jenkinsClient, ticker: time.NewTicker(time.Hour), done: make(chan struct{})}
+		collected := make(chan error)
+		go func() {
+			collected <- collector.collect("default", "stopped")
+		}()
+		<-collecting
+
+		// doesn't wait for the hanging Jenkins
+		collector.stop()
+		close(unblock)
+
+		assert.NoError(t, <-collected)
+		assert.False(t, jenkinsJobs.DeleteLabelValues("default", "stopped"), "metrics shouldn't be set after stop")
+	})
+}
+
+func TestEnsureRuntimeCollector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+	jenkinsClient.EXPECT().GetQueue().Return(newQueue(t, `{"Raw": {"Items": []}}`), nil).AnyTimes()
+	jenkinsClient.EXPECT().GetAllNodes().Return(nil, nil).AnyTimes()
+	jenkinsClient.EXPECT().GetAllJobNames().Return(nil, nil).AnyTimes()
+
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "collector", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			RuntimeMetrics: &v1alpha2.RuntimeMetrics{IntervalSeconds: 30},
+		},
+	}
+	collector := func() (*runtimeCollector, bool) {
+		collectors.mutex.Lock()
+		defer collectors.mutex.Unlock()
+		c, found := collectors.collectors[collectors.key(jenkins.Namespace, jenkins.Name)]
+		return c, found
+	}
+
+	EnsureRuntimeCollector(jenkins, jenkinsClient)
+	first, found := collector()
+	require.True(t, found)
+	assert.Equal(t, int64(30), int64(first.interval.Seconds()))
+
+	EnsureRuntimeCollector(jenkins, jenkinsClient)
+	second, _ := collector()
+	assert.True(t, first == second, "collector should not be restarted if the interval didn't change")
+
+	jenkins.Spec.RuntimeMetrics.IntervalSeconds = 0
+	EnsureRuntimeCollector(jenkins, jenkinsClient)
+	third, _ := collector()
+	assert.Equal(t, defaultRuntimeMetricsInterval, third.interval)
+
+	jenkins.Spec.RuntimeMetrics = nil
+	EnsureRuntimeCollector(jenkins, jenkinsClient)
+	_, found = collector()
+	assert.False(t, found)
+	assert.False(t, jenkinsJobs.DeleteLabelValues("default", "collector"), "metrics should be removed")
+}
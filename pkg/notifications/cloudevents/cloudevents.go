@@ -0,0 +1,171 @@
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SpecVersion is the version of CloudEvents specification
+	SpecVersion = "1.0"
+
+	// TypePrefix is the prefix of CloudEvents type attribute, it's followed by the lowercase reason type
+	TypePrefix = "io.jenkins.operator."
+
+	// StructuredContentType is the HTTP content type of structured mode
+	StructuredContentType = "application/cloudevents+json"
+
+	// DataContentType is the content type of the event data
+	DataContentType = "application/json"
+
+	headerPrefix = "ce-"
+)
+
+// CloudEvents is a CloudEvents 1.0 notification service.
+type CloudEvents struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of CloudEvents.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *CloudEvents {
+	return &CloudEvents{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Event is representation of CloudEvent in structured mode.
+type Event struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Data      `json:"data"`
+}
+
+// Data is representation of CloudEvent data.
+type Data struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	Phase     string   `json:"phase"`
+	Level     string   `json:"level"`
+	Reason    string   `json:"reason"`
+	Short     []string `json:"short"`
+	Verbose   []string `json:"verbose"`
+}
+
+// EventType returns CloudEvents type attribute for the reason, e.g. io.jenkins.operator.podrestart.
+func EventType(r reason.Reason) string {
+	return TypePrefix + strings.ToLower(reason.TypeName(r))
+}
+
+func (c CloudEvents) source(e event.Event) string {
+	if len(c.config.CloudEvents.Source) > 0 {
+		return c.config.CloudEvents.Source
+	}
+
+	return fmt.Sprintf("/apis/%s/namespaces/%s/jenkins/%s", v1alpha2.GroupVersion.String(), e.Jenkins.Namespace, e.Jenkins.Name)
+}
+
+func (c CloudEvents) generateEvent(e event.Event) Event {
+	return Event{
+		SpecVersion:     SpecVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          c.source(e),
+		Type:            EventType(e.Reason),
+		Subject:         e.Jenkins.Name,
+		Time:            time.Now().UTC(),
+		DataContentType: DataContentType,
+		Data: Data{
+			Name:      e.Jenkins.Name,
+			Namespace: e.Jenkins.Namespace,
+			Phase:     string(e.Phase),
+			Level:     string(e.Level),
+			Reason:    reason.TypeName(e.Reason),
+			Short:     e.Reason.Short(),
+			Verbose:   e.Reason.Verbose(),
+		},
+	}
+}
+
+func (c CloudEvents) newRequest(url string, ce Event) (*http.Request, error) {
+	if c.config.CloudEvents.Mode == v1alpha2.CloudEventsModeStructured {
+		body, err := json.Marshal(ce)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		request, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		request.Header.Set("Content-Type", StructuredContentType)
+		return request, nil
+	}
+
+	body, err := json.Marshal(ce.Data)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	request, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	request.Header.Set(headerPrefix+"specversion", ce.SpecVersion)
+	request.Header.Set(headerPrefix+"id", ce.ID)
+	request.Header.Set(headerPrefix+"source", ce.Source)
+	request.Header.Set(headerPrefix+"type", ce.Type)
+	request.Header.Set(headerPrefix+"subject", ce.Subject)
+	request.Header.Set(headerPrefix+"time", ce.Time.Format(time.RFC3339Nano))
+	request.Header.Set("Content-Type", ce.DataContentType)
+	return request, nil
+}
+
+// Send is function for sending directly to API.
+func (c CloudEvents) Send(e event.Event) error {
+	secret := &corev1.Secret{}
+	selector := c.config.CloudEvents.URLSecretKeySelector
+
+	err := c.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	secretValue := string(secret.Data[selector.Key])
+	if secretValue == "" {
+		return errors.Errorf("CloudEvents sink URL is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	request, err := c.newRequest(secretValue, c.generateEvent(e))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.New(fmt.Sprintf("Invalid response from server: %s", resp.Status))
+	}
+
+	return nil
+}
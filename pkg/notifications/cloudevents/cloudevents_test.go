@@ -0,0 +1,158 @@
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testPhase     = event.PhaseUser
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testReason    = reason.NewPodRestart(
+		reason.KubernetesSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	)
+	testLevel = v1alpha2.NotificationLevelWarning
+
+	testURLSelectorKeyName = "test-url-selector"
+	testSecretName         = "test-secret"
+	testEvent              = event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+)
+
+func newCloudEvents(t *testing.T, mode v1alpha2.CloudEventsMode, url string) CloudEvents {
+	fakeClient := fake.NewClientBuilder().Build()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecretName,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			testURLSelectorKeyName: []byte(url),
+		},
+	}
+	require.NoError(t, fakeClient.Create(context.TODO(), secret))
+
+	return CloudEvents{k8sClient: fakeClient, config: v1alpha2.Notification{
+		CloudEvents: &v1alpha2.CloudEvents{
+			URLSecretKeySelector: v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: testSecretName,
+				},
+				Key: testURLSelectorKeyName,
+			},
+			Mode: mode,
+		},
+	}}
+}
+
+func assertData(t *testing.T, data Data) {
+	assert.Equal(t, testCrName, data.Name)
+	assert.Equal(t, testNamespace, data.Namespace)
+	assert.Equal(t, string(testPhase), data.Phase)
+	assert.Equal(t, string(testLevel), data.Level)
+	assert.Equal(t, "PodRestart", data.Reason)
+	assert.Equal(t, testReason.Short(), data.Short)
+	assert.Equal(t, testReason.Verbose(), data.Verbose)
+}
+
+func TestCloudEvents_Send(t *testing.T) {
+	t.Run("binary mode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, SpecVersion, r.Header.Get("ce-specversion"))
+			assert.Equal(t, "io.jenkins.operator.podrestart", r.Header.Get("ce-type"))
+			assert.Equal(t, "/apis/jenkins.io/v1alpha2/namespaces/default/jenkins/test-cr", r.Header.Get("ce-source"))
+			assert.Equal(t, testCrName, r.Header.Get("ce-subject"))
+			assert.NotEmpty(t, r.Header.Get("ce-id"))
+			assert.NotEmpty(t, r.Header.Get("ce-time"))
+			assert.Equal(t, DataContentType, r.Header.Get("Content-Type"))
+
+			var data Data
+			if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+				t.Fatal(err)
+			}
+			assertData(t, data)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := newCloudEvents(t, v1alpha2.CloudEventsModeBinary, server.URL).Send(testEvent)
+		assert.NoError(t, err)
+	})
+
+	t.Run("structured mode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, StructuredContentType, r.Header.Get("Content-Type"))
+			assert.Empty(t, r.Header.Get("ce-type"))
+
+			var ce Event
+			if err := json.NewDecoder(r.Body).Decode(&ce); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, SpecVersion, ce.SpecVersion)
+			assert.Equal(t, "io.jenkins.operator.podrestart", ce.Type)
+			assert.Equal(t, "/apis/jenkins.io/v1alpha2/namespaces/default/jenkins/test-cr", ce.Source)
+			assert.Equal(t, testCrName, ce.Subject)
+			assert.Equal(t, DataContentType, ce.DataContentType)
+			assert.NotEmpty(t, ce.ID)
+			assert.False(t, ce.Time.IsZero())
+			assertData(t, ce.Data)
+		}))
+		defer server.Close()
+
+		err := newCloudEvents(t, v1alpha2.CloudEventsModeStructured, server.URL).Send(testEvent)
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom source", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/custom/source", r.Header.Get("ce-source"))
+		}))
+		defer server.Close()
+
+		cloudEvents := newCloudEvents(t, "", server.URL)
+		cloudEvents.config.CloudEvents.Source = "/custom/source"
+		err := cloudEvents.Send(testEvent)
+		assert.NoError(t, err)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		err := newCloudEvents(t, v1alpha2.CloudEventsModeBinary, server.URL).Send(testEvent)
+		assert.Error(t, err)
+	})
+}
+
+func TestEventType(t *testing.T) {
+	assert.Equal(t, "io.jenkins.operator.podrestart", EventType(reason.NewPodRestart(reason.OperatorSource, []string{"test"})))
+	assert.Equal(t, "io.jenkins.operator.baseconfigurationcomplete", EventType(reason.NewBaseConfigurationComplete(reason.OperatorSource, []string{"test"})))
+	assert.Equal(t, "io.jenkins.operator.groovyscriptexecutionfailed", EventType(reason.NewGroovyScriptExecutionFailed(reason.OperatorSource, []string{"test"})))
+}
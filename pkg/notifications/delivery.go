@@ -0,0 +1,237 @@
+package notifications
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// queueSize is the maximum number of notifications waiting for delivery
+	queueSize = 100
+	// workers is the number of notifications delivered at the same time
+	workers = 5
+)
+
+// delivery is a single notification sent by the provider.
+type delivery struct {
+	provider Provider
+	config   v1alpha2.Notification
+	event    event.Event
+}
+
+type rateLimiter struct {
+	limiter   flowcontrol.RateLimiter
+	perMinute int32
+	burst     int32
+}
+
+// dispatcher deduplicates, rate limits and queues notifications, dispatch and prune must be called from a single goroutine.
+type dispatcher struct {
+	queue    chan delivery
+	sent     map[string]map[string]time.Time
+	limiters map[string]rateLimiter
+	// notifications are names of the dispatched notifications by Jenkins CR
+	notifications  map[string]map[string]struct{}
+	now            func() time.Time
+	initialBackoff time.Duration
+}
+
+func newDispatcher(size int) *dispatcher {
+	return &dispatcher{
+		queue:          make(chan delivery, size),
+		sent:           map[string]map[string]time.Time{},
+		limiters:       map[string]rateLimiter{},
+		notifications:  map[string]map[string]struct{}{},
+		now:            time.Now,
+		initialBackoff: time.Second,
+	}
+}
+
+func jenkinsKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+func notificationKey(config v1alpha2.Notification, e event.Event) string {
+	return fmt.Sprintf("%s/%s", jenkinsKey(e.Jenkins.Namespace, e.Jenkins.Name), config.Name)
+}
+
+func messagesHash(e event.Event) string {
+	hash := sha256.New()
+	hash.Write([]byte(reason.TypeName(e.Reason)))
+	hash.Write([]byte(strings.Join(e.Reason.Short(), "\x00")))
+	hash.Write([]byte(strings.Join(e.Reason.Verbose(), "\x00")))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// dispatch queues the notification unless it's a duplicate, it's rate limited or the queue is full.
+func (d *dispatcher) dispatch(provider Provider, config v1alpha2.Notification, e event.Event) {
+	logger := log.Log.WithValues("cr", e.Jenkins.Name, "notification", config.Name)
+	key := notificationKey(config, e)
+	item := delivery{provider: provider, config: config, event: e}
+	d.track(e, config)
+
+	hash, duplicate := d.isDuplicate(key, config, e)
+	if duplicate {
+		logger.V(log.VDebug).Info("Skipping duplicated notification")
+		recordNotification(item, resultDropped)
+		return
+	}
+	if !d.allow(key, config) {
+		logger.V(log.VWarn).Info("Notification rate limit exceeded, skipping notification")
+		recordNotification(item, resultDropped)
+		return
+	}
+
+	select {
+	case d.queue <- item:
+		// dropped notifications don't suppress their retries
+		d.markSent(key, hash)
+	default:
+		logger.V(log.VWarn).Info("Notification queue is full, skipping notification")
+		recordNotification(item, resultDropped)
+	}
+}
+
+func (d *dispatcher) track(e event.Event, config v1alpha2.Notification) {
+	key := jenkinsKey(e.Jenkins.Namespace, e.Jenkins.Name)
+	if d.notifications[key] == nil {
+		d.notifications[key] = map[string]struct{}{}
+	}
+	d.notifications[key][config.Name] = struct{}{}
+}
+
+// isDuplicate tells if the same notification has been sent within the deduplication window, it returns the hash
+// of the notification messages, empty if the deduplication is disabled
+func (d *dispatcher) isDuplicate(key string, config v1alpha2.Notification, e event.Event) (string, bool) {
+	if config.Delivery == nil || config.Delivery.DeduplicationWindowSeconds <= 0 {
+		return "", false
+	}
+
+	window := time.Duration(config.Delivery.DeduplicationWindowSeconds) * time.Second
+	now := d.now()
+	sent, found := d.sent[key]
+	if !found {
+		sent = map[string]time.Time{}
+		d.sent[key] = sent
+	}
+	for hash, sentTime := range sent {
+		if now.Sub(sentTime) >= window {
+			delete(sent, hash)
+		}
+	}
+
+	hash := messagesHash(e)
+	_, found = sent[hash]
+	return hash, found
+}
+
+func (d *dispatcher) markSent(key, hash string) {
+	if len(hash) == 0 {
+		return
+	}
+	if d.sent[key] == nil {
+		d.sent[key] = map[string]time.Time{}
+	}
+	d.sent[key][hash] = d.now()
+}
+
+// prune forgets the state and removes the metrics of the Jenkins CR notifications which aren't configured anymore,
+// all of them when the Jenkins CR has been deleted (nil notifications)
+func (d *dispatcher) prune(namespace, name string, notifications []v1alpha2.Notification) {
+	key := jenkinsKey(namespace, name)
+	configured := map[string]bool{}
+	for _, notification := range notifications {
+		configured[notification.Name] = true
+	}
+	for notification := range d.notifications[key] {
+		if configured[notification] {
+			continue
+		}
+		delete(d.sent, key+"/"+notification)
+		delete(d.limiters, key+"/"+notification)
+		delete(d.notifications[key], notification)
+		deleteNotificationMetrics(namespace, name, notification)
+	}
+	if len(d.notifications[key]) == 0 {
+		delete(d.notifications, key)
+	}
+}
+
+func (d *dispatcher) allow(key string, config v1alpha2.Notification) bool {
+	if config.Delivery == nil || config.Delivery.RateLimitPerMinute <= 0 {
+		return true
+	}
+
+	perMinute := config.Delivery.RateLimitPerMinute
+	burst := config.Delivery.RateLimitBurst
+	if burst <= 0 {
+		burst = 1
+	}
+	limiter, found := d.limiters[key]
+	if !found || limiter.perMinute != perMinute || limiter.burst != burst {
+		limiter = rateLimiter{
+			limiter:   flowcontrol.NewTokenBucketRateLimiter(float32(perMinute)/60, int(burst)),
+			perMinute: perMinute,
+			burst:     burst,
+		}
+		d.limiters[key] = limiter
+	}
+
+	return limiter.limiter.TryAccept()
+}
+
+func (d *dispatcher) backoff(config v1alpha2.Notification) wait.Backoff {
+	backoff := wait.Backoff{Duration: d.initialBackoff, Factor: 2, Jitter: 0.1, Steps: 1}
+	if config.Delivery != nil {
+		backoff.Steps += int(config.Delivery.MaxRetries)
+		if config.Delivery.InitialBackoffSeconds > 0 {
+			backoff.Duration = time.Duration(config.Delivery.InitialBackoffSeconds) * time.Second
+		}
+	}
+
+	return backoff
+}
+
+// run delivers queued notifications until the queue is closed.
+func (d *dispatcher) run() {
+	for item := range d.queue {
+		d.send(item)
+	}
+}
+
+func (d *dispatcher) send(item delivery) {
+	logger := log.Log.WithValues("cr", item.event.Jenkins.Name)
+	if _, deleted := item.event.Reason.(*reason.JenkinsDeletion); deleted {
+		// the notifications of the deleted Jenkins CR have been already pruned when this one was queued
+		defer deleteNotificationMetrics(item.event.Jenkins.Namespace, item.event.Jenkins.Name, item.config.Name)
+	}
+	err := retry.OnError(d.backoff(item.config), func(error) bool { return true }, func() error {
+		return item.provider.Send(item.event)
+	})
+	if err != nil {
+		recordNotification(item, resultFailed)
+		wrapped := errors.WithMessage(err,
+			fmt.Sprintf("failed to send notification '%s'", item.config.Name))
+		if log.Debug {
+			logger.Error(nil, fmt.Sprintf("%+v", wrapped))
+		} else {
+			logger.Error(nil, fmt.Sprintf("%s", wrapped))
+		}
+		return
+	}
+
+	recordNotification(item, resultSent)
+}
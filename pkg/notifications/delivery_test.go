@@ -0,0 +1,178 @@
+package notifications
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeProvider struct {
+	calls    int
+	failures int
+}
+
+func (f *fakeProvider) Send(event.Event) error {
+	f.calls++
+	if f.calls <= f.failures {
+		return errors.New("send failed")
+	}
+	return nil
+}
+
+func newTestEvent(name string, messages ...string) event.Event {
+	return event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+		},
+		Phase:  event.PhaseBase,
+		Level:  v1alpha2.NotificationLevelWarning,
+		Reason: reason.NewReconcileLoopFailed(reason.OperatorSource, messages),
+	}
+}
+
+func sentCount(e event.Event, config v1alpha2.Notification, result string) float64 {
+	return testutil.ToFloat64(notificationsTotal.WithLabelValues(e.Jenkins.Namespace, e.Jenkins.Name, config.Name, result))
+}
+
+func TestDispatcher_dispatch(t *testing.T) {
+	t.Run("deduplication", func(t *testing.T) {
+		now := time.Now()
+		d := newDispatcher(10)
+		d.now = func() time.Time { return now }
+		config := v1alpha2.Notification{
+			Name:     "dedup",
+			Delivery: &v1alpha2.NotificationDelivery{DeduplicationWindowSeconds: 60},
+		}
+		e := newTestEvent("dedup", "error")
+
+		d.dispatch(&fakeProvider{}, config, e)
+		d.dispatch(&fakeProvider{}, config, e)
+		d.dispatch(&fakeProvider{}, config, newTestEvent("dedup", "another error"))
+		assert.Len(t, d.queue, 2)
+		assert.Equal(t, float64(1), sentCount(e, config, resultDropped))
+
+		now = now.Add(time.Minute)
+		d.dispatch(&fakeProvider{}, config, e)
+		assert.Len(t, d.queue, 3)
+	})
+	t.Run("dropped notification doesn't suppress its retry", func(t *testing.T) {
+		d := newDispatcher(1)
+		config := v1alpha2.Notification{
+			Name:     "dedup-dropped",
+			Delivery: &v1alpha2.NotificationDelivery{DeduplicationWindowSeconds: 60},
+		}
+		d.queue <- delivery{}
+		e := newTestEvent("dedup-dropped", "error")
+
+		d.dispatch(&fakeProvider{}, config, e)
+		assert.Equal(t, float64(1), sentCount(e, config, resultDropped))
+		<-d.queue
+		d.dispatch(&fakeProvider{}, config, e)
+
+		assert.Len(t, d.queue, 1)
+	})
+	t.Run("rate limit", func(t *testing.T) {
+		d := newDispatcher(10)
+		config := v1alpha2.Notification{
+			Name:     "ratelimit",
+			Delivery: &v1alpha2.NotificationDelivery{RateLimitPerMinute: 1, RateLimitBurst: 2},
+		}
+		e := newTestEvent("ratelimit", "error")
+
+		for i := 0; i < 4; i++ {
+			d.dispatch(&fakeProvider{}, config, e)
+		}
+
+		assert.Len(t, d.queue, 2)
+		assert.Equal(t, float64(2), sentCount(e, config, resultDropped))
+	})
+	t.Run("queue is full", func(t *testing.T) {
+		d := newDispatcher(1)
+		config := v1alpha2.Notification{Name: "queue"}
+		e := newTestEvent("queue", "error")
+
+		d.dispatch(&fakeProvider{}, config, e)
+		d.dispatch(&fakeProvider{}, config, e)
+
+		assert.Len(t, d.queue, 1)
+		assert.Equal(t, float64(1), sentCount(e, config, resultDropped))
+	})
+}
+
+func TestDispatcher_send(t *testing.T) {
+	t.Run("without retries", func(t *testing.T) {
+		d := newDispatcher(1)
+		d.initialBackoff = time.Millisecond
+		provider := &fakeProvider{failures: 1}
+		config := v1alpha2.Notification{Name: "no-retries"}
+		e := newTestEvent("no-retries", "error")
+
+		d.send(delivery{provider: provider, config: config, event: e})
+
+		assert.Equal(t, 1, provider.calls)
+		assert.Equal(t, float64(1), sentCount(e, config, resultFailed))
+	})
+	t.Run("succeeds after retries", func(t *testing.T) {
+		d := newDispatcher(1)
+		d.initialBackoff = time.Millisecond
+		provider := &fakeProvider{failures: 2}
+		config := v1alpha2.Notification{Name: "retries", Delivery: &v1alpha2.NotificationDelivery{MaxRetries: 3}}
+		e := newTestEvent("retries", "error")
+
+		d.send(delivery{provider: provider, config: config, event: e})
+
+		assert.Equal(t, 3, provider.calls)
+		assert.Equal(t, float64(1), sentCount(e, config, resultSent))
+		assert.Equal(t, float64(0), sentCount(e, config, resultFailed))
+	})
+	t.Run("fails after retries", func(t *testing.T) {
+		d := newDispatcher(1)
+		d.initialBackoff = time.Millisecond
+		provider := &fakeProvider{failures: 10}
+		config := v1alpha2.Notification{Name: "retries-failed", Delivery: &v1alpha2.NotificationDelivery{MaxRetries: 2}}
+		e := newTestEvent("retries-failed", "error")
+
+		d.send(delivery{provider: provider, config: config, event: e})
+
+		assert.Equal(t, 3, provider.calls)
+		assert.Equal(t, float64(1), sentCount(e, config, resultFailed))
+	})
+}
+
+func TestDispatcher_prune(t *testing.T) {
+	d := newDispatcher(10)
+	kept := v1alpha2.Notification{Name: "kept", Delivery: &v1alpha2.NotificationDelivery{DeduplicationWindowSeconds: 60, RateLimitPerMinute: 10}}
+	removed := v1alpha2.Notification{Name: "removed", Delivery: &v1alpha2.NotificationDelivery{DeduplicationWindowSeconds: 60, RateLimitPerMinute: 10}}
+	e := newTestEvent("prune", "error")
+	d.dispatch(&fakeProvider{}, kept, e)
+	d.dispatch(&fakeProvider{}, removed, e)
+	d.dispatch(&fakeProvider{}, removed, e)
+
+	t.Run("removed notification", func(t *testing.T) {
+		d.prune("default", "prune", []v1alpha2.Notification{kept})
+
+		assert.Contains(t, d.sent, notificationKey(kept, e))
+		assert.Contains(t, d.limiters, notificationKey(kept, e))
+		assert.NotContains(t, d.sent, notificationKey(removed, e))
+		assert.NotContains(t, d.limiters, notificationKey(removed, e))
+		assert.False(t, notificationsTotal.DeleteLabelValues("default", "prune", removed.Name, resultDropped))
+	})
+	t.Run("deleted Jenkins CR", func(t *testing.T) {
+		d.prune("default", "prune", nil)
+
+		assert.Empty(t, d.sent)
+		assert.Empty(t, d.limiters)
+		assert.Empty(t, d.notifications)
+	})
+}
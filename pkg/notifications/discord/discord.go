@@ -0,0 +1,155 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	infoColor    = 0x439FE0
+	warningColor = 0xE81123
+	defaultColor = 0xC8C8C8
+)
+
+// Discord is a Discord notification service.
+type Discord struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of Discord.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *Discord {
+	return &Discord{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Message is representation of json message.
+type Message struct {
+	Embeds []Embed `json:"embeds"`
+}
+
+// Embed is representation of json embed.
+type Embed struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Color       int     `json:"color"`
+	Fields      []Field `json:"fields"`
+}
+
+// Field is representation of json field.
+type Field struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func (d Discord) getStatusColor(logLevel v1alpha2.NotificationLevel) int {
+	switch logLevel {
+	case v1alpha2.NotificationLevelInfo:
+		return infoColor
+	case v1alpha2.NotificationLevelWarning:
+		return warningColor
+	default:
+		return defaultColor
+	}
+}
+
+func (d Discord) generateMessage(e event.Event) (Message, error) {
+	description := " - " + strings.Join(provider.Messages(d.config, e), "\n - ")
+
+	title, err := provider.Title(d.config, e)
+	if err != nil {
+		return Message{}, err
+	}
+	if body, ok, err := provider.Body(d.config, e); err != nil {
+		return Message{}, err
+	} else if ok {
+		description = body
+	}
+
+	dm := Message{
+		Embeds: []Embed{
+			{
+				Title:       title,
+				Description: description,
+				Color:       d.getStatusColor(e.Level),
+				Fields: []Field{
+					{
+						Name:   provider.NamespaceFieldName,
+						Value:  e.Jenkins.Namespace,
+						Inline: true,
+					},
+					{
+						Name:   provider.CrNameFieldName,
+						Value:  e.Jenkins.Name,
+						Inline: true,
+					},
+					{
+						Name:   provider.PhaseFieldName,
+						Value:  string(e.Phase),
+						Inline: true,
+					},
+				},
+			},
+		},
+	}
+
+	return dm, nil
+}
+
+// Send is function for sending directly to API.
+func (d Discord) Send(e event.Event) error {
+	secret := &corev1.Secret{}
+	selector := d.config.Discord.WebHookURLSecretKeySelector
+
+	err := d.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	secretValue := string(secret.Data[selector.Key])
+	if secretValue == "" {
+		return errors.Errorf("Discord WebHook URL is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	message, err := d.generateMessage(e)
+	if err != nil {
+		return err
+	}
+
+	msg, err := json.Marshal(message)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	request, err := http.NewRequest("POST", secretValue, bytes.NewBuffer(msg))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return errors.New(fmt.Sprintf("Invalid response from server: %s", resp.Status))
+	}
+
+	return nil
+}
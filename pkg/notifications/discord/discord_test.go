@@ -0,0 +1,167 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testPhase     = event.PhaseUser
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testReason    = reason.NewPodRestart(
+		reason.KubernetesSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	)
+	testLevel = v1alpha2.NotificationLevelWarning
+)
+
+func TestDiscord_Send(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().Build()
+	testURLSelectorKeyName := "test-url-selector"
+	testSecretName := "test-secret"
+
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+
+	discord := Discord{k8sClient: fakeClient, config: v1alpha2.Notification{
+		Discord: &v1alpha2.Discord{
+			WebHookURLSecretKeySelector: v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: testSecretName,
+				},
+				Key: testURLSelectorKeyName,
+			},
+		},
+	}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message Message
+		decoder := json.NewDecoder(r.Body)
+		err := decoder.Decode(&message)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mainEmbed := message.Embeds[0]
+
+		assert.Equal(t, mainEmbed.Title, provider.NotificationTitle(e))
+		assert.Equal(t, mainEmbed.Description, " - "+strings.Join(e.Reason.Short(), "\n - "))
+		assert.Equal(t, mainEmbed.Color, discord.getStatusColor(e.Level))
+		for _, field := range mainEmbed.Fields {
+			switch field.Name {
+			case provider.PhaseFieldName:
+				assert.Equal(t, field.Value, string(e.Phase))
+			case provider.CrNameFieldName:
+				assert.Equal(t, field.Value, e.Jenkins.Name)
+			case provider.NamespaceFieldName:
+				assert.Equal(t, field.Value, e.Jenkins.Namespace)
+			default:
+				t.Errorf("Unexpected field %+v", field)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecretName,
+			Namespace: testNamespace,
+		},
+
+		Data: map[string][]byte{
+			testURLSelectorKeyName: []byte(server.URL),
+		},
+	}
+
+	err := fakeClient.Create(context.TODO(), secret)
+	assert.NoError(t, err)
+
+	err = discord.Send(e)
+	assert.NoError(t, err)
+}
+
+func TestGenerateMessage(t *testing.T) {
+	t.Run("verbose", func(t *testing.T) {
+		d := Discord{
+			config: v1alpha2.Notification{
+				Verbose: true,
+			},
+		}
+		e := event.Event{
+			Jenkins: v1alpha2.Jenkins{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ąśćńółżź",
+					Namespace: "test-namespace",
+				},
+			},
+			Phase:  event.PhaseBase,
+			Level:  v1alpha2.NotificationLevelInfo,
+			Reason: reason.NewUndefined(reason.KubernetesSource, []string{"test-string"}, "test-verbose"),
+		}
+
+		message, err := d.generateMessage(e)
+
+		assert.NoError(t, err)
+		mainEmbed := message.Embeds[0]
+		assert.Equal(t, " - test-verbose", mainEmbed.Description)
+		assert.Equal(t, infoColor, mainEmbed.Color)
+		assert.Equal(t, e.Jenkins.Namespace, mainEmbed.Fields[0].Value)
+		assert.Equal(t, e.Jenkins.Name, mainEmbed.Fields[1].Value)
+		assert.Equal(t, string(e.Phase), mainEmbed.Fields[2].Value)
+	})
+
+	t.Run("with template", func(t *testing.T) {
+		d := Discord{
+			config: v1alpha2.Notification{
+				Template: &v1alpha2.NotificationTemplate{
+					Title: "{{ .Jenkins.Name }}",
+					Body:  "{{ .Reason }}",
+				},
+			},
+		}
+		e := event.Event{
+			Jenkins: v1alpha2.Jenkins{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testCrName,
+				},
+			},
+			Phase:  testPhase,
+			Level:  testLevel,
+			Reason: testReason,
+		}
+
+		message, err := d.generateMessage(e)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCrName, message.Embeds[0].Title)
+		assert.Equal(t, "PodRestart", message.Embeds[0].Description)
+	})
+}
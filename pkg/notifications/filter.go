@@ -0,0 +1,60 @@
+package notifications
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/pagerduty"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+)
+
+// shouldSend checks if the event matches the logging level and filter of the notification.
+func shouldSend(notificationConfig v1alpha2.Notification, e event.Event) bool {
+	isInfoEvent := e.Level == v1alpha2.NotificationLevelInfo
+	wantsWarning := notificationConfig.LoggingLevel == v1alpha2.NotificationLevelWarning
+	// PagerDuty incidents are resolved by info events
+	resolvesIncident := notificationConfig.PagerDuty != nil && pagerduty.IsResolveReason(e.Reason)
+	if isInfoEvent && wantsWarning && !resolvesIncident {
+		return false
+	}
+
+	filter := notificationConfig.Filter
+	if filter == nil {
+		return true
+	}
+
+	reasonName := v1alpha2.NotificationReason(reason.TypeName(e.Reason))
+	if containsReason(filter.ExcludeReasons, reasonName) {
+		return false
+	}
+	if len(filter.IncludeReasons) > 0 && !containsReason(filter.IncludeReasons, reasonName) {
+		return false
+	}
+
+	phase := v1alpha2.NotificationPhase(e.Phase)
+	if containsPhase(filter.ExcludePhases, phase) {
+		return false
+	}
+	if len(filter.IncludePhases) > 0 && !containsPhase(filter.IncludePhases, phase) {
+		return false
+	}
+
+	return true
+}
+
+func containsReason(reasons []v1alpha2.NotificationReason, value v1alpha2.NotificationReason) bool {
+	for _, r := range reasons {
+		if r == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPhase(phases []v1alpha2.NotificationPhase, value v1alpha2.NotificationPhase) bool {
+	for _, p := range phases {
+		if p == value {
+			return true
+		}
+	}
+	return false
+}
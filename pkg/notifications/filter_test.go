@@ -0,0 +1,101 @@
+package notifications
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldSend(t *testing.T) {
+	podRestart := event.Event{
+		Phase:  event.PhaseBase,
+		Level:  v1alpha2.NotificationLevelWarning,
+		Reason: reason.NewPodRestart(reason.KubernetesSource, []string{"test"}),
+	}
+	userComplete := event.Event{
+		Phase:  event.PhaseUser,
+		Level:  v1alpha2.NotificationLevelInfo,
+		Reason: reason.NewUserConfigurationComplete(reason.OperatorSource, []string{"test"}),
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		config := v1alpha2.Notification{LoggingLevel: v1alpha2.NotificationLevelInfo}
+
+		assert.True(t, shouldSend(config, podRestart))
+		assert.True(t, shouldSend(config, userComplete))
+	})
+	t.Run("warning level skips info events", func(t *testing.T) {
+		config := v1alpha2.Notification{LoggingLevel: v1alpha2.NotificationLevelWarning}
+
+		assert.True(t, shouldSend(config, podRestart))
+		assert.False(t, shouldSend(config, userComplete))
+	})
+	t.Run("warning level passes PagerDuty resolve events", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			LoggingLevel: v1alpha2.NotificationLevelWarning,
+			PagerDuty:    &v1alpha2.PagerDuty{},
+		}
+
+		assert.True(t, shouldSend(config, podRestart))
+		assert.True(t, shouldSend(config, userComplete))
+	})
+	t.Run("include reasons", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			LoggingLevel: v1alpha2.NotificationLevelInfo,
+			Filter: &v1alpha2.NotificationFilter{
+				IncludeReasons: []v1alpha2.NotificationReason{"PodRestart", "ReconcileLoopFailed"},
+			},
+		}
+
+		assert.True(t, shouldSend(config, podRestart))
+		assert.False(t, shouldSend(config, userComplete))
+	})
+	t.Run("exclude reasons", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			LoggingLevel: v1alpha2.NotificationLevelInfo,
+			Filter: &v1alpha2.NotificationFilter{
+				ExcludeReasons: []v1alpha2.NotificationReason{"PodRestart"},
+			},
+		}
+
+		assert.False(t, shouldSend(config, podRestart))
+		assert.True(t, shouldSend(config, userComplete))
+	})
+	t.Run("include phases", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			LoggingLevel: v1alpha2.NotificationLevelInfo,
+			Filter: &v1alpha2.NotificationFilter{
+				IncludePhases: []v1alpha2.NotificationPhase{"user"},
+			},
+		}
+
+		assert.False(t, shouldSend(config, podRestart))
+		assert.True(t, shouldSend(config, userComplete))
+	})
+	t.Run("exclude phases", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			LoggingLevel: v1alpha2.NotificationLevelInfo,
+			Filter: &v1alpha2.NotificationFilter{
+				ExcludePhases: []v1alpha2.NotificationPhase{"user"},
+			},
+		}
+
+		assert.True(t, shouldSend(config, podRestart))
+		assert.False(t, shouldSend(config, userComplete))
+	})
+	t.Run("exclusion takes precedence", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			LoggingLevel: v1alpha2.NotificationLevelInfo,
+			Filter: &v1alpha2.NotificationFilter{
+				IncludeReasons: []v1alpha2.NotificationReason{"PodRestart"},
+				ExcludePhases:  []v1alpha2.NotificationPhase{"base"},
+			},
+		}
+
+		assert.False(t, shouldSend(config, podRestart))
+	})
+}
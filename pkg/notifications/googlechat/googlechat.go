@@ -0,0 +1,103 @@
+package googlechat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GoogleChat is a Google Chat notification service.
+type GoogleChat struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of GoogleChat.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *GoogleChat {
+	return &GoogleChat{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Message is representation of json message.
+type Message struct {
+	Text string `json:"text"`
+}
+
+func (g GoogleChat) generateMessage(e event.Event) (Message, error) {
+	body := " - " + strings.Join(provider.Messages(g.config, e), "\n - ")
+
+	title, err := provider.Title(g.config, e)
+	if err != nil {
+		return Message{}, err
+	}
+	if templateBody, ok, err := provider.Body(g.config, e); err != nil {
+		return Message{}, err
+	} else if ok {
+		body = templateBody
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("*%s*\n", title))
+	text.WriteString(body + "\n\n")
+	text.WriteString(fmt.Sprintf("*%s:* %s\n", provider.NamespaceFieldName, e.Jenkins.Namespace))
+	text.WriteString(fmt.Sprintf("*%s:* %s\n", provider.CrNameFieldName, e.Jenkins.Name))
+	text.WriteString(fmt.Sprintf("*%s:* %s", provider.PhaseFieldName, e.Phase))
+
+	return Message{Text: text.String()}, nil
+}
+
+// Send is function for sending directly to API.
+func (g GoogleChat) Send(e event.Event) error {
+	secret := &corev1.Secret{}
+	selector := g.config.GoogleChat.WebHookURLSecretKeySelector
+
+	err := g.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	secretValue := string(secret.Data[selector.Key])
+	if secretValue == "" {
+		return errors.Errorf("Google Chat WebHook URL is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	message, err := g.generateMessage(e)
+	if err != nil {
+		return err
+	}
+
+	msg, err := json.Marshal(message)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	request, err := http.NewRequest("POST", secretValue, bytes.NewBuffer(msg))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	resp, err := g.httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(fmt.Sprintf("Invalid response from server: %s", resp.Status))
+	}
+
+	return nil
+}
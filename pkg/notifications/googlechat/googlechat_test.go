@@ -0,0 +1,148 @@
+package googlechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testPhase     = event.PhaseUser
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testReason    = reason.NewPodRestart(
+		reason.KubernetesSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	)
+	testLevel = v1alpha2.NotificationLevelWarning
+)
+
+func TestGoogleChat_Send(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().Build()
+	testURLSelectorKeyName := "test-url-selector"
+	testSecretName := "test-secret"
+
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+
+	googleChat := GoogleChat{k8sClient: fakeClient, config: v1alpha2.Notification{
+		GoogleChat: &v1alpha2.GoogleChat{
+			WebHookURLSecretKeySelector: v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: testSecretName,
+				},
+				Key: testURLSelectorKeyName,
+			},
+		},
+	}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message Message
+		decoder := json.NewDecoder(r.Body)
+		err := decoder.Decode(&message)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, message.Text, fmt.Sprintf("*%s*\n", provider.NotificationTitle(e)))
+		assert.Contains(t, message.Text, " - "+e.Reason.Short()[0])
+		assert.Contains(t, message.Text, fmt.Sprintf("*%s:* %s", provider.NamespaceFieldName, e.Jenkins.Namespace))
+		assert.Contains(t, message.Text, fmt.Sprintf("*%s:* %s", provider.CrNameFieldName, e.Jenkins.Name))
+		assert.Contains(t, message.Text, fmt.Sprintf("*%s:* %s", provider.PhaseFieldName, e.Phase))
+	}))
+
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecretName,
+			Namespace: testNamespace,
+		},
+
+		Data: map[string][]byte{
+			testURLSelectorKeyName: []byte(server.URL),
+		},
+	}
+
+	err := fakeClient.Create(context.TODO(), secret)
+	assert.NoError(t, err)
+
+	err = googleChat.Send(e)
+	assert.NoError(t, err)
+}
+
+func TestGenerateMessage(t *testing.T) {
+	t.Run("verbose", func(t *testing.T) {
+		g := GoogleChat{
+			config: v1alpha2.Notification{
+				Verbose: true,
+			},
+		}
+		e := event.Event{
+			Jenkins: v1alpha2.Jenkins{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ąśćńółżź",
+					Namespace: "test-namespace",
+				},
+			},
+			Phase:  event.PhaseBase,
+			Level:  v1alpha2.NotificationLevelInfo,
+			Reason: reason.NewUndefined(reason.KubernetesSource, []string{"test-string"}, "test-verbose"),
+		}
+
+		message, err := g.generateMessage(e)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "*"+provider.InfoTitleText+"*\n - test-verbose\n\n*Namespace:* test-namespace\n*CR Name:* ąśćńółżź\n*Phase:* base", message.Text)
+	})
+
+	t.Run("with template", func(t *testing.T) {
+		g := GoogleChat{
+			config: v1alpha2.Notification{
+				Template: &v1alpha2.NotificationTemplate{
+					Title: "{{ .Jenkins.Name }}",
+					Body:  "<users/all> {{ .Reason }}",
+				},
+			},
+		}
+		e := event.Event{
+			Jenkins: v1alpha2.Jenkins{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testCrName,
+				},
+			},
+			Phase:  testPhase,
+			Level:  testLevel,
+			Reason: testReason,
+		}
+
+		message, err := g.generateMessage(e)
+
+		assert.NoError(t, err)
+		assert.Contains(t, message.Text, "*test-cr*\n<users/all> PodRestart\n\n")
+	})
+}
@@ -67,27 +67,29 @@ func (m MailGun) getStatusColor(logLevel v1alpha2.NotificationLevel) event.Statu
 	}
 }
 
-func (m MailGun) generateMessage(event event.Event) string {
+func (m MailGun) generateMessage(event event.Event) (string, error) {
 	var statusMessage strings.Builder
-	var reasons string
-
-	if m.config.Verbose {
-		reasons = strings.TrimRight(strings.Join(event.Reason.Verbose(), "</li><li>"), "<li>")
-	} else {
-		reasons = strings.TrimRight(strings.Join(event.Reason.Short(), "</li><li>"), "<li>")
-	}
+	reasons := strings.TrimRight(strings.Join(provider.Messages(m.config, event), "</li><li>"), "<li>")
 
 	statusMessage.WriteString("<ul><li>")
 	statusMessage.WriteString(reasons)
 	statusMessage.WriteString("</ul>")
 
 	statusColor := m.getStatusColor(event.Level)
-	messageTitle := provider.NotificationTitle(event)
+	messageTitle, err := provider.Title(m.config, event)
+	if err != nil {
+		return "", err
+	}
 	message := statusMessage.String()
+	if body, ok, err := provider.Body(m.config, event); err != nil {
+		return "", err
+	} else if ok {
+		message = body
+	}
 	crName := event.Jenkins.Name
 	phase := event.Phase
 
-	return fmt.Sprintf(content, statusColor, messageTitle, message, crName, phase)
+	return fmt.Sprintf(content, statusColor, messageTitle, message, crName, phase), nil
 }
 
 // Send is function for sending directly to API
@@ -109,11 +111,19 @@ func (m MailGun) Send(event event.Event) error {
 
 	mg := mailgun.NewMailgun(m.config.Mailgun.Domain, secretValue)
 	from := fmt.Sprintf("Jenkins Operator Notifier <%s>", m.config.Mailgun.From)
-	subject := provider.NotificationTitle(event)
+	subject, err := provider.Title(m.config, event)
+	if err != nil {
+		return err
+	}
 	recipient := m.config.Mailgun.Recipient
 
+	html, err := m.generateMessage(event)
+	if err != nil {
+		return err
+	}
+
 	msg := mg.NewMessage(from, subject, "", recipient)
-	msg.SetHtml(m.generateMessage(event))
+	msg.SetHtml(html)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 
@@ -49,7 +49,8 @@ func TestGenerateMessages(t *testing.T) {
 		statusMessage.WriteString(r)
 		statusMessage.WriteString("</ul>")
 
-		want := s.generateMessage(e)
+		want, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		got := fmt.Sprintf(content, s.getStatusColor(e.Level),
 			provider.NotificationTitle(e), statusMessage.String(), e.Jenkins.Name, e.Phase)
@@ -90,7 +91,8 @@ func TestGenerateMessages(t *testing.T) {
 		statusMessage.WriteString(r)
 		statusMessage.WriteString("</ul>")
 
-		want := s.generateMessage(e)
+		want, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		got := fmt.Sprintf(content, s.getStatusColor(e.Level),
 			provider.NotificationTitle(e), statusMessage.String(), e.Jenkins.Name, e.Phase)
@@ -131,7 +133,8 @@ func TestGenerateMessages(t *testing.T) {
 		statusMessage.WriteString(r)
 		statusMessage.WriteString("</ul>")
 
-		want := s.generateMessage(e)
+		want, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		got := fmt.Sprintf(content, s.getStatusColor(e.Level),
 			provider.NotificationTitle(e), statusMessage.String(), e.Jenkins.Name, e.Phase)
@@ -172,7 +175,8 @@ func TestGenerateMessages(t *testing.T) {
 		statusMessage.WriteString(r)
 		statusMessage.WriteString("</ul>")
 
-		want := s.generateMessage(e)
+		want, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		got := fmt.Sprintf(content, s.getStatusColor(e.Level),
 			provider.NotificationTitle(e), statusMessage.String(), e.Jenkins.Name, e.Phase)
@@ -0,0 +1,157 @@
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	infoColor    = "#439FE0"
+	warningColor = "#E81123"
+	defaultColor = "#C8C8C8"
+)
+
+// Mattermost is a Mattermost notification service.
+type Mattermost struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of Mattermost.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *Mattermost {
+	return &Mattermost{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Message is representation of json message.
+type Message struct {
+	Attachments []Attachment `json:"attachments"`
+}
+
+// Attachment is representation of json attachment.
+type Attachment struct {
+	Fallback string            `json:"fallback"`
+	Color    event.StatusColor `json:"color"`
+	Title    string            `json:"title"`
+	Text     string            `json:"text"`
+	Fields   []Field           `json:"fields"`
+}
+
+// Field is representation of json field.
+type Field struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+func (m Mattermost) getStatusColor(logLevel v1alpha2.NotificationLevel) event.StatusColor {
+	switch logLevel {
+	case v1alpha2.NotificationLevelInfo:
+		return infoColor
+	case v1alpha2.NotificationLevelWarning:
+		return warningColor
+	default:
+		return defaultColor
+	}
+}
+
+func (m Mattermost) generateMessage(e event.Event) (Message, error) {
+	text := " - " + strings.Join(provider.Messages(m.config, e), "\n - ")
+
+	title, err := provider.Title(m.config, e)
+	if err != nil {
+		return Message{}, err
+	}
+	if body, ok, err := provider.Body(m.config, e); err != nil {
+		return Message{}, err
+	} else if ok {
+		text = body
+	}
+
+	mm := Message{
+		Attachments: []Attachment{
+			{
+				Fallback: title,
+				Color:    m.getStatusColor(e.Level),
+				Title:    title,
+				Text:     text,
+				Fields: []Field{
+					{
+						Title: provider.NamespaceFieldName,
+						Value: e.Jenkins.Namespace,
+						Short: true,
+					},
+					{
+						Title: provider.CrNameFieldName,
+						Value: e.Jenkins.Name,
+						Short: true,
+					},
+					{
+						Title: provider.PhaseFieldName,
+						Value: string(e.Phase),
+						Short: true,
+					},
+				},
+			},
+		},
+	}
+
+	return mm, nil
+}
+
+// Send is function for sending directly to API.
+func (m Mattermost) Send(e event.Event) error {
+	secret := &corev1.Secret{}
+	selector := m.config.Mattermost.WebHookURLSecretKeySelector
+
+	err := m.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	secretValue := string(secret.Data[selector.Key])
+	if secretValue == "" {
+		return errors.Errorf("Mattermost WebHook URL is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	message, err := m.generateMessage(e)
+	if err != nil {
+		return err
+	}
+
+	msg, err := json.Marshal(message)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	request, err := http.NewRequest("POST", secretValue, bytes.NewBuffer(msg))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(fmt.Sprintf("Invalid response from server: %s", resp.Status))
+	}
+
+	return nil
+}
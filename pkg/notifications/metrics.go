@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	resultSent    = "sent"
+	resultDropped = "dropped"
+	resultFailed  = "failed"
+)
+
+var notificationsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "jenkins_operator_notifications_total",
+		Help: "Number of notifications by result: sent, dropped (duplicated, rate limited or queue full) or failed.",
+	},
+	[]string{"namespace", "name", "notification", "result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(notificationsTotal)
+}
+
+func recordNotification(d delivery, result string) {
+	notificationsTotal.WithLabelValues(d.event.Jenkins.Namespace, d.event.Jenkins.Name, d.config.Name, result).Inc()
+}
+
+func deleteNotificationMetrics(namespace, name, notification string) {
+	for _, result := range []string{resultSent, resultDropped, resultFailed} {
+		notificationsTotal.DeleteLabelValues(namespace, name, notification, result)
+	}
+}
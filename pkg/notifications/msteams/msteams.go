@@ -69,16 +69,21 @@ func (t Teams) getStatusColor(logLevel v1alpha2.NotificationLevel) event.StatusC
 	}
 }
 
-func (t Teams) generateMessage(e event.Event) Message {
-	var reason string
-	if t.config.Verbose {
-		reason = strings.Join(e.Reason.Verbose(), "\n\n - ")
-	} else {
-		reason = strings.Join(e.Reason.Short(), "\n\n - ")
+func (t Teams) generateMessage(e event.Event) (Message, error) {
+	reason := strings.Join(provider.Messages(t.config, e), "\n\n - ")
+
+	title, err := provider.Title(t.config, e)
+	if err != nil {
+		return Message{}, err
+	}
+	if body, ok, err := provider.Body(t.config, e); err != nil {
+		return Message{}, err
+	} else if ok {
+		reason = body
 	}
 
 	tm := Message{
-		Title:      provider.NotificationTitle(e),
+		Title:      title,
 		Type:       "MessageCard",
 		Context:    "https://schema.org/extensions",
 		ThemeColor: t.getStatusColor(e.Level),
@@ -104,7 +109,7 @@ func (t Teams) generateMessage(e event.Event) Message {
 		Summary: reason,
 	}
 
-	return tm
+	return tm, nil
 }
 
 // Send is function for sending directly to API
@@ -123,7 +128,12 @@ func (t Teams) Send(e event.Event) error {
 		return errors.Errorf("Microsoft Teams WebHook URL is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
 	}
 
-	msg, err := json.Marshal(t.generateMessage(e))
+	message, err := t.generateMessage(e)
+	if err != nil {
+		return err
+	}
+
+	msg, err := json.Marshal(message)
 	if err != nil {
 		return errors.WithStack(err)
 	}
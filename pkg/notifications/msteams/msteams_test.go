@@ -141,7 +141,8 @@ func TestGenerateMessages(t *testing.T) {
 			Reason: res,
 		}
 
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		msg := strings.Join(e.Reason.Verbose(), "\n\n - ")
 
@@ -184,7 +185,8 @@ func TestGenerateMessages(t *testing.T) {
 			Reason: res,
 		}
 
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		msg := strings.Join(e.Reason.Verbose(), "\n\n - ")
 
@@ -227,7 +229,8 @@ func TestGenerateMessages(t *testing.T) {
 			Reason: res,
 		}
 
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		msg := strings.Join(e.Reason.Verbose(), "\n\n - ")
 
@@ -269,7 +272,8 @@ func TestGenerateMessages(t *testing.T) {
 			Reason: res,
 		}
 
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		msg := strings.Join(e.Reason.Verbose(), "\n\n - ")
 
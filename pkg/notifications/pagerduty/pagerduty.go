@@ -0,0 +1,179 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EventsAPIURL is the PagerDuty Events API v2 endpoint
+	EventsAPIURL = "https://events.pagerduty.com/v2/enqueue"
+
+	actionTrigger = "trigger"
+	actionResolve = "resolve"
+
+	severityWarning = "warning"
+	severityInfo    = "info"
+
+	maxSummaryLength = 1024
+)
+
+// PagerDuty is a PagerDuty Events API v2 notification service.
+type PagerDuty struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+	url        string
+}
+
+// New returns instance of PagerDuty.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *PagerDuty {
+	return &PagerDuty{k8sClient: k8sClient, config: config, httpClient: httpClient, url: EventsAPIURL}
+}
+
+// Event is representation of PagerDuty Events API v2 json message.
+type Event struct {
+	RoutingKey  string   `json:"routing_key"`
+	EventAction string   `json:"event_action"`
+	DedupKey    string   `json:"dedup_key"`
+	Payload     *Payload `json:"payload,omitempty"`
+}
+
+// Payload is representation of PagerDuty alert details.
+type Payload struct {
+	Summary       string        `json:"summary"`
+	Source        string        `json:"source"`
+	Severity      string        `json:"severity"`
+	Component     string        `json:"component"`
+	Group         string        `json:"group"`
+	Class         string        `json:"class"`
+	CustomDetails CustomDetails `json:"custom_details"`
+}
+
+// CustomDetails is representation of PagerDuty alert additional details.
+type CustomDetails struct {
+	Phase    string   `json:"phase"`
+	Messages []string `json:"messages"`
+}
+
+// IsResolveReason checks if the reason resolves previously triggered incidents.
+func IsResolveReason(r reason.Reason) bool {
+	switch r.(type) {
+	case *reason.BaseConfigurationComplete, *reason.UserConfigurationComplete:
+		return true
+	default:
+		return false
+	}
+}
+
+// DedupKey returns incident key which is stable for Jenkins CR and phase.
+func DedupKey(e event.Event) string {
+	return fmt.Sprintf("jenkins-operator/%s/%s/%s", e.Jenkins.Namespace, e.Jenkins.Name, e.Phase)
+}
+
+func getSeverity(level v1alpha2.NotificationLevel) string {
+	switch level {
+	case v1alpha2.NotificationLevelWarning:
+		return severityWarning
+	default:
+		return severityInfo
+	}
+}
+
+func (p PagerDuty) generateEvent(e event.Event, routingKey string) (*Event, error) {
+	if IsResolveReason(e.Reason) {
+		return &Event{RoutingKey: routingKey, EventAction: actionResolve, DedupKey: DedupKey(e)}, nil
+	}
+	if e.Level != v1alpha2.NotificationLevelWarning {
+		return nil, nil
+	}
+
+	title, err := provider.Title(p.config, e)
+	if err != nil {
+		return nil, err
+	}
+	messages := provider.Messages(p.config, e)
+	summary := fmt.Sprintf("%s: %s", title, strings.Join(messages, "; "))
+	if len(summary) > maxSummaryLength {
+		summary = summary[:maxSummaryLength]
+	}
+
+	return &Event{
+		RoutingKey:  routingKey,
+		EventAction: actionTrigger,
+		DedupKey:    DedupKey(e),
+		Payload: &Payload{
+			Summary:   summary,
+			Source:    fmt.Sprintf("%s/%s", e.Jenkins.Namespace, e.Jenkins.Name),
+			Severity:  getSeverity(e.Level),
+			Component: "jenkins",
+			Group:     e.Jenkins.Namespace,
+			Class:     reason.TypeName(e.Reason),
+			CustomDetails: CustomDetails{
+				Phase:    string(e.Phase),
+				Messages: messages,
+			},
+		},
+	}, nil
+}
+
+// Send is function for sending directly to API.
+func (p PagerDuty) Send(e event.Event) error {
+	secret := &corev1.Secret{}
+	selector := p.config.PagerDuty.RoutingKeySecretKeySelector
+
+	err := p.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: e.Jenkins.Namespace}, secret)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	secretValue := string(secret.Data[selector.Key])
+	if secretValue == "" {
+		return errors.Errorf("PagerDuty routing key is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	pagerDutyEvent, err := p.generateEvent(e, secretValue)
+	if err != nil {
+		return err
+	}
+	if pagerDutyEvent == nil {
+		return nil // nothing to trigger or resolve
+	}
+
+	msg, err := json.Marshal(pagerDutyEvent)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	request, err := http.NewRequest("POST", p.url, bytes.NewBuffer(msg))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return errors.New(fmt.Sprintf("Invalid response from server: %s", resp.Status))
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testCrName         = "test-cr"
+	testNamespace      = "default"
+	testRoutingKey     = "test-routing-key"
+	testRoutingKeyName = "test-routing-key-selector"
+	testSecretName     = "test-secret"
+	testJenkins        = v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testCrName,
+			Namespace: testNamespace,
+		},
+	}
+)
+
+func newPagerDuty(t *testing.T, url string) PagerDuty {
+	fakeClient := fake.NewClientBuilder().Build()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSecretName,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			testRoutingKeyName: []byte(testRoutingKey),
+		},
+	}
+	require.NoError(t, fakeClient.Create(context.TODO(), secret))
+
+	return PagerDuty{k8sClient: fakeClient, url: url, config: v1alpha2.Notification{
+		PagerDuty: &v1alpha2.PagerDuty{
+			RoutingKeySecretKeySelector: v1alpha2.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: testSecretName,
+				},
+				Key: testRoutingKeyName,
+			},
+		},
+	}}
+}
+
+func TestPagerDuty_Send(t *testing.T) {
+	t.Run("trigger on warning", func(t *testing.T) {
+		e := event.Event{
+			Jenkins: testJenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelWarning,
+			Reason:  reason.NewReconcileLoopFailed(reason.OperatorSource, []string{"reconcile failed"}),
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var pagerDutyEvent Event
+			if err := json.NewDecoder(r.Body).Decode(&pagerDutyEvent); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, testRoutingKey, pagerDutyEvent.RoutingKey)
+			assert.Equal(t, actionTrigger, pagerDutyEvent.EventAction)
+			assert.Equal(t, "jenkins-operator/default/test-cr/base", pagerDutyEvent.DedupKey)
+			require.NotNil(t, pagerDutyEvent.Payload)
+			assert.Equal(t, provider.WarnTitleText+": reconcile failed", pagerDutyEvent.Payload.Summary)
+			assert.Equal(t, severityWarning, pagerDutyEvent.Payload.Severity)
+			assert.Equal(t, "default/test-cr", pagerDutyEvent.Payload.Source)
+			assert.Equal(t, "ReconcileLoopFailed", pagerDutyEvent.Payload.Class)
+			assert.Equal(t, []string{"reconcile failed"}, pagerDutyEvent.Payload.CustomDetails.Messages)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := newPagerDuty(t, server.URL).Send(e)
+		assert.NoError(t, err)
+	})
+
+	t.Run("resolve on configuration complete", func(t *testing.T) {
+		e := event.Event{
+			Jenkins: testJenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelInfo,
+			Reason:  reason.NewBaseConfigurationComplete(reason.OperatorSource, []string{"base configuration complete"}),
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var pagerDutyEvent Event
+			if err := json.NewDecoder(r.Body).Decode(&pagerDutyEvent); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, actionResolve, pagerDutyEvent.EventAction)
+			assert.Equal(t, "jenkins-operator/default/test-cr/base", pagerDutyEvent.DedupKey)
+			assert.Nil(t, pagerDutyEvent.Payload)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := newPagerDuty(t, server.URL).Send(e)
+		assert.NoError(t, err)
+	})
+
+	t.Run("skip other info events", func(t *testing.T) {
+		e := event.Event{
+			Jenkins: testJenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelInfo,
+			Reason:  reason.NewPodCreation(reason.OperatorSource, []string{"pod created"}),
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request")
+		}))
+		defer server.Close()
+
+		err := newPagerDuty(t, server.URL).Send(e)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		e := event.Event{
+			Jenkins: testJenkins,
+			Phase:   event.PhaseUser,
+			Level:   v1alpha2.NotificationLevelWarning,
+			Reason:  reason.NewUserConfigurationFailed(reason.HumanSource, []string{"validation failed"}),
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		err := newPagerDuty(t, server.URL).Send(e)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,69 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/provider"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// policyNotifications returns notifications of JenkinsNotificationPolicies selecting the Jenkins CR,
+// their names are prefixed with the policy name to not collide with the Jenkins CR notifications.
+// Policies with an invalid selector and invalid notifications are logged and skipped.
+func policyNotifications(k8sClient k8sclient.Client, jenkins v1alpha2.Jenkins) ([]v1alpha2.Notification, error) {
+	policies := &v1alpha2.JenkinsNotificationPolicyList{}
+	err := k8sClient.List(context.TODO(), policies, k8sclient.InNamespace(jenkins.Namespace))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	sort.Slice(policies.Items, func(i, j int) bool {
+		return policies.Items[i].Name < policies.Items[j].Name
+	})
+
+	logger := log.Log.WithValues("cr", jenkins.Name)
+	var notifications []v1alpha2.Notification
+	for _, policy := range policies.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.Selector)
+		if err != nil {
+			logger.V(log.VWarn).Info(fmt.Sprintf("Skipping JenkinsNotificationPolicy '%s/%s', invalid selector: %s", policy.Namespace, policy.Name, err))
+			continue
+		}
+		if !selector.Matches(labels.Set(jenkins.Labels)) {
+			continue
+		}
+
+		for index, notification := range policy.Spec.Notifications {
+			if messages := provider.ValidateNotification(notification); len(messages) > 0 {
+				logger.V(log.VWarn).Info(fmt.Sprintf("Skipping notification '%s' of JenkinsNotificationPolicy '%s/%s', spec.notifications[%d]: %s",
+					notification.Name, policy.Namespace, policy.Name, index, strings.Join(messages, "; ")))
+				continue
+			}
+			notification.Name = policy.Name + "/" + notification.Name
+			notifications = append(notifications, notification)
+		}
+	}
+
+	return notifications, nil
+}
+
+// mergeNotifications returns notifications defined in the Jenkins CR and in the JenkinsNotificationPolicies selecting it.
+func mergeNotifications(k8sClient k8sclient.Client, jenkins v1alpha2.Jenkins) ([]v1alpha2.Notification, error) {
+	notifications := append([]v1alpha2.Notification{}, jenkins.Spec.Notifications...)
+
+	fromPolicies, err := policyNotifications(k8sClient, jenkins)
+	if err != nil {
+		return notifications, err
+	}
+
+	return append(notifications, fromPolicies...), nil
+}
@@ -0,0 +1,104 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMergeNotifications(t *testing.T) {
+	err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+	require.NoError(t, err)
+
+	jenkins := v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "default",
+			Labels:    map[string]string{"environment": "production"},
+		},
+		Spec: v1alpha2.JenkinsSpec{
+			Notifications: []v1alpha2.Notification{{Name: "cr", Slack: &v1alpha2.Slack{}}},
+		},
+	}
+	newPolicy := func(name, namespace string, selector metav1.LabelSelector) *v1alpha2.JenkinsNotificationPolicy {
+		return &v1alpha2.JenkinsNotificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha2.JenkinsNotificationPolicySpec{
+				Selector:      selector,
+				Notifications: []v1alpha2.Notification{{Name: "slack", Slack: &v1alpha2.Slack{}}},
+			},
+		}
+	}
+
+	t.Run("no policies", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().Build()
+
+		notifications, err := mergeNotifications(fakeClient, jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, jenkins.Spec.Notifications, notifications)
+	})
+	t.Run("selected policies", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().Build()
+		policies := []*v1alpha2.JenkinsNotificationPolicy{
+			newPolicy("production", "default", metav1.LabelSelector{MatchLabels: map[string]string{"environment": "production"}}),
+			newPolicy("all", "default", metav1.LabelSelector{}),
+			newPolicy("staging", "default", metav1.LabelSelector{MatchLabels: map[string]string{"environment": "staging"}}),
+			newPolicy("other-namespace", "other", metav1.LabelSelector{}),
+		}
+		for _, policy := range policies {
+			require.NoError(t, fakeClient.Create(context.TODO(), policy))
+		}
+
+		notifications, err := mergeNotifications(fakeClient, jenkins)
+
+		assert.NoError(t, err)
+		var names []string
+		for _, notification := range notifications {
+			names = append(names, notification.Name)
+		}
+		assert.Equal(t, []string{"cr", "all/slack", "production/slack"}, names)
+	})
+	t.Run("invalid selector", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().Build()
+		selector := metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "environment", Operator: "invalid"}},
+		}
+		require.NoError(t, fakeClient.Create(context.TODO(), newPolicy("invalid", "default", selector)))
+		require.NoError(t, fakeClient.Create(context.TODO(), newPolicy("valid", "default", metav1.LabelSelector{})))
+
+		notifications, err := mergeNotifications(fakeClient, jenkins)
+
+		assert.NoError(t, err)
+		var names []string
+		for _, notification := range notifications {
+			names = append(names, notification.Name)
+		}
+		assert.Equal(t, []string{"cr", "valid/slack"}, names)
+	})
+	t.Run("invalid notification", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().Build()
+		policy := newPolicy("policy", "default", metav1.LabelSelector{})
+		policy.Spec.Notifications = append(policy.Spec.Notifications,
+			v1alpha2.Notification{Name: "template", Slack: &v1alpha2.Slack{}, Template: &v1alpha2.NotificationTemplate{Title: "{{ .Jenkins.Name"}},
+			v1alpha2.Notification{Name: "smtp", SMTP: &v1alpha2.SMTP{}},
+		)
+		require.NoError(t, fakeClient.Create(context.TODO(), policy))
+
+		notifications, err := mergeNotifications(fakeClient, jenkins)
+
+		assert.NoError(t, err)
+		var names []string
+		for _, notification := range notifications {
+			names = append(names, notification.Name)
+		}
+		assert.Equal(t, []string{"cr", "policy/slack"}, names)
+	})
+}
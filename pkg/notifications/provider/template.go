@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/internal/render"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/pkg/errors"
+)
+
+// TemplateData is the data available in notification templates.
+type TemplateData struct {
+	Jenkins  v1alpha2.Jenkins
+	Phase    event.Phase
+	Level    v1alpha2.NotificationLevel
+	Reason   string
+	Messages []string
+	Short    []string
+	Verbose  []string
+}
+
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// ParseTemplate parses notification template, name is used in error messages.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return t, nil
+}
+
+// ValidateNotification returns problems of the notification configuration, messages are relative to the notification.
+func ValidateNotification(notification v1alpha2.Notification) []string {
+	var messages []string
+	if smtp := notification.SMTP; smtp != nil && len(smtp.To) == 0 && len(smtp.Recipients) == 0 {
+		messages = append(messages, "smtp requires to or recipients")
+	}
+	if notification.Template == nil {
+		return messages
+	}
+	if _, err := ParseTemplate("title", notification.Template.Title); err != nil {
+		messages = append(messages, fmt.Sprintf("template.title is invalid: %s", err))
+	}
+	if _, err := ParseTemplate("body", notification.Template.Body); err != nil {
+		messages = append(messages, fmt.Sprintf("template.body is invalid: %s", err))
+	}
+
+	return messages
+}
+
+// Messages returns verbose or short reason messages depending on notification configuration.
+func Messages(config v1alpha2.Notification, e event.Event) []string {
+	if config.Verbose {
+		return e.Reason.Verbose()
+	}
+
+	return e.Reason.Short()
+}
+
+// Title returns notification title rendered from the template, or the default title if the template is not set.
+func Title(config v1alpha2.Notification, e event.Event) (string, error) {
+	if config.Template == nil || len(config.Template.Title) == 0 {
+		return NotificationTitle(e), nil
+	}
+
+	return execute(config.Name+"-title", config.Template.Title, config, e)
+}
+
+// Body returns notification message rendered from the template, ok is false if the template is not set.
+func Body(config v1alpha2.Notification, e event.Event) (body string, ok bool, err error) {
+	if config.Template == nil || len(config.Template.Body) == 0 {
+		return "", false, nil
+	}
+
+	body, err = execute(config.Name+"-body", config.Template.Body, config, e)
+	return body, err == nil, err
+}
+
+func execute(name, text string, config v1alpha2.Notification, e event.Event) (string, error) {
+	t, err := ParseTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	return render.Render(t, TemplateData{
+		Jenkins:  e.Jenkins,
+		Phase:    e.Phase,
+		Level:    e.Level,
+		Reason:   reason.TypeName(e.Reason),
+		Messages: Messages(config, e),
+		Short:    e.Reason.Short(),
+		Verbose:  e.Reason.Verbose(),
+	})
+}
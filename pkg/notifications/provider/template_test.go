@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testEvent = event.Event{
+	Jenkins: v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cr",
+			Namespace: "test-namespace",
+			Labels:    map[string]string{"environment": "production"},
+		},
+	},
+	Phase:  event.PhaseBase,
+	Level:  v1alpha2.NotificationLevelWarning,
+	Reason: reason.NewPodRestart(reason.OperatorSource, []string{"short"}, "verbose"),
+}
+
+func TestTitle(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		title, err := Title(v1alpha2.Notification{}, testEvent)
+
+		assert.NoError(t, err)
+		assert.Equal(t, WarnTitleText, title)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			Template: &v1alpha2.NotificationTemplate{
+				Title: `[{{ index .Jenkins.Labels "environment" | upper }}] {{ .Reason }} in {{ .Jenkins.Namespace }}/{{ .Jenkins.Name }}`,
+			},
+		}
+
+		title, err := Title(config, testEvent)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "[PRODUCTION] PodRestart in test-namespace/test-cr", title)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			Template: &v1alpha2.NotificationTemplate{
+				Title: `{{ .Jenkins.Name`,
+			},
+		}
+
+		_, err := Title(config, testEvent)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBody(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		_, ok, err := Body(v1alpha2.Notification{}, testEvent)
+
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("short messages", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			Template: &v1alpha2.NotificationTemplate{
+				Body: `{{ join .Messages ", " }} - runbook: https://runbooks.example.com/{{ .Phase }}`,
+			},
+		}
+
+		body, ok, err := Body(config, testEvent)
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "Jenkins master pod restarted by operator: short - runbook: https://runbooks.example.com/base", body)
+	})
+
+	t.Run("verbose messages", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			Verbose: true,
+			Template: &v1alpha2.NotificationTemplate{
+				Body: `{{ range .Messages }}{{ . }}{{ end }}`,
+			},
+		}
+
+		body, ok, err := Body(config, testEvent)
+
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "Jenkins master pod restarted by operator: verbose", body)
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		config := v1alpha2.Notification{
+			Template: &v1alpha2.NotificationTemplate{
+				Body: `{{ .NotExisting }}`,
+			},
+		}
+
+		_, ok, err := Body(config, testEvent)
+
+		assert.Error(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -1,6 +1,9 @@
 package reason
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+)
 
 const (
 	// OperatorSource defines that notification concerns operator
@@ -65,6 +68,11 @@ type UserConfigurationComplete struct {
 	Undefined
 }
 
+// JenkinsDeletion informs that Jenkins CR has been deleted and cleaned up.
+type JenkinsDeletion struct {
+	Undefined
+}
+
 // NewUndefined returns new instance of Undefined.
 func NewUndefined(source Source, short []string, verbose ...string) *Undefined {
 	return &Undefined{source: source, short: short, verbose: checkIfVerboseEmpty(short, verbose)}
@@ -171,6 +179,17 @@ func NewUserConfigurationComplete(source Source, short []string, verbose ...stri
 	}
 }
 
+// NewJenkinsDeletion returns new instance of JenkinsDeletion.
+func NewJenkinsDeletion(source Source, short []string, verbose ...string) *JenkinsDeletion {
+	return &JenkinsDeletion{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
 // Source is enum type that informs us what triggered notification.
 type Source string
 
@@ -189,6 +208,19 @@ func (p Undefined) HasMessages() bool {
 	return len(p.short) > 0 || len(p.verbose) > 0
 }
 
+// TypeName returns name of the reason type, e.g. PodRestart.
+func TypeName(reason Reason) string {
+	reasonType := reflect.TypeOf(reason)
+	if reasonType == nil {
+		return ""
+	}
+	if reasonType.Kind() == reflect.Ptr {
+		reasonType = reasonType.Elem()
+	}
+
+	return reasonType.Name()
+}
+
 func checkIfVerboseEmpty(short []string, verbose []string) []string {
 	if len(verbose) == 0 {
 		return short
@@ -75,3 +75,17 @@ func TestPodRestartPrepend(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("Jenkins master pod restarted by %s:", KubernetesSource), podRestart.short[0])
 	})
 }
+
+func TestTypeName(t *testing.T) {
+	t.Run("pointer", func(t *testing.T) {
+		assert.Equal(t, "PodRestart", TypeName(NewPodRestart(KubernetesSource, []string{"test"})))
+	})
+
+	t.Run("value", func(t *testing.T) {
+		assert.Equal(t, "BaseConfigurationComplete", TypeName(BaseConfigurationComplete{}))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		assert.Equal(t, "", TypeName(nil))
+	})
+}
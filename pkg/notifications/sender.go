@@ -3,22 +3,30 @@ package notifications
 import (
 	"fmt"
 	"net/http"
-	"reflect"
 	"strings"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/cloudevents"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/discord"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/googlechat"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/mailgun"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/mattermost"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/msteams"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/pagerduty"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/slack"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/smtp"
-
-	"github.com/pkg/errors"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/webhook"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// httpTimeout is the maximum time of a single notification request.
+const httpTimeout = 30 * time.Second
+
 // Provider is the communication service handler.
 type Provider interface {
 	Send(event event.Event) error
@@ -26,7 +34,12 @@ type Provider interface {
 
 // Listen listens for incoming events and send it as notifications.
 func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8sclient.Client) {
-	httpClient := http.Client{}
+	httpClient := http.Client{Timeout: httpTimeout}
+	notificationDispatcher := newDispatcher(queueSize)
+	for i := 0; i < workers; i++ {
+		go notificationDispatcher.run()
+	}
+
 	for e := range events {
 		logger := log.Log.WithValues("cr", e.Jenkins.Name)
 
@@ -37,45 +50,54 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 
 		k8sEvent.Emit(&e.Jenkins,
 			eventLevelToKubernetesEventType(e.Level),
-			k8sevent.Reason(reflect.TypeOf(e.Reason).Name()),
+			k8sevent.Reason(reason.TypeName(e.Reason)),
 			strings.Join(e.Reason.Short(), "; "),
 		)
 
-		for _, notificationConfig := range e.Jenkins.Spec.Notifications {
-			var err error
+		notificationConfigs, err := mergeNotifications(k8sClient, e.Jenkins)
+		if err != nil {
+			logger.V(log.VWarn).Info(fmt.Sprintf("Failed to get notification policies: %+v", err))
+		}
+
+		for _, notificationConfig := range notificationConfigs {
 			var provider Provider
 			switch {
 			case notificationConfig.Slack != nil:
 				provider = slack.New(k8sClient, notificationConfig, httpClient)
 			case notificationConfig.Teams != nil:
 				provider = msteams.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.Discord != nil:
+				provider = discord.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.Mattermost != nil:
+				provider = mattermost.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.GoogleChat != nil:
+				provider = googlechat.New(k8sClient, notificationConfig, httpClient)
 			case notificationConfig.Mailgun != nil:
 				provider = mailgun.New(k8sClient, notificationConfig)
 			case notificationConfig.SMTP != nil:
 				provider = smtp.New(k8sClient, notificationConfig)
+			case notificationConfig.Webhook != nil:
+				provider = webhook.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.CloudEvents != nil:
+				provider = cloudevents.New(k8sClient, notificationConfig, httpClient)
+			case notificationConfig.PagerDuty != nil:
+				provider = pagerduty.New(k8sClient, notificationConfig, httpClient)
 			default:
 				logger.V(log.VWarn).Info(fmt.Sprintf("Unknown notification service `%+v`", notificationConfig))
 				continue
 			}
 
-			isInfoEvent := e.Level == v1alpha2.NotificationLevelInfo
-			wantsWarning := notificationConfig.LoggingLevel == v1alpha2.NotificationLevelWarning
-			if isInfoEvent && wantsWarning {
+			if !shouldSend(notificationConfig, e) {
 				continue // skip the event
 			}
 
-			go func(notificationConfig v1alpha2.Notification) {
-				err = provider.Send(e)
-				if err != nil {
-					wrapped := errors.WithMessage(err,
-						fmt.Sprintf("failed to send notification '%s'", notificationConfig.Name))
-					if log.Debug {
-						logger.Error(nil, fmt.Sprintf("%+v", wrapped))
-					} else {
-						logger.Error(nil, fmt.Sprintf("%s", wrapped))
-					}
-				}
-			}(notificationConfig)
+			notificationDispatcher.dispatch(provider, notificationConfig, e)
+		}
+
+		if _, deleted := e.Reason.(*reason.JenkinsDeletion); deleted {
+			notificationDispatcher.prune(e.Jenkins.Namespace, e.Jenkins.Name, nil)
+		} else if err == nil {
+			notificationDispatcher.prune(e.Jenkins.Namespace, e.Jenkins.Name, notificationConfigs)
 		}
 	}
 }
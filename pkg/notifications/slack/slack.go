@@ -70,28 +70,33 @@ func (s Slack) getStatusColor(logLevel v1alpha2.NotificationLevel) event.StatusC
 	}
 }
 
-func (s Slack) generateMessage(e event.Event) Message {
+func (s Slack) generateMessage(e event.Event) (Message, error) {
 	var messageStringBuilder strings.Builder
-	if s.config.Verbose {
-		for _, msg := range e.Reason.Verbose() {
-			messageStringBuilder.WriteString("\n - " + msg + "\n")
-		}
-	} else {
-		for _, msg := range e.Reason.Short() {
-			messageStringBuilder.WriteString("\n - " + msg + "\n")
-		}
+	for _, msg := range provider.Messages(s.config, e) {
+		messageStringBuilder.WriteString("\n - " + msg + "\n")
+	}
+	message := messageStringBuilder.String()
+
+	title, err := provider.Title(s.config, e)
+	if err != nil {
+		return Message{}, err
+	}
+	if body, ok, err := provider.Body(s.config, e); err != nil {
+		return Message{}, err
+	} else if ok {
+		message = body
 	}
 
 	sm := Message{
 		Attachments: []Attachment{
 			{
-				Title:    provider.NotificationTitle(e),
+				Title:    title,
 				Fallback: "",
 				Color:    s.getStatusColor(e.Level),
 				Fields: []Field{
 					{
 						Title: "",
-						Value: messageStringBuilder.String(),
+						Value: message,
 						Short: false,
 					},
 					{
@@ -114,7 +119,7 @@ func (s Slack) generateMessage(e event.Event) Message {
 		},
 	}
 
-	return sm
+	return sm, nil
 }
 
 // Send is function for sending directly to API.
@@ -127,7 +132,12 @@ func (s Slack) Send(e event.Event) error {
 		return err
 	}
 
-	slackMessage, err := json.Marshal(s.generateMessage(e))
+	message, err := s.generateMessage(e)
+	if err != nil {
+		return err
+	}
+
+	slackMessage, err := json.Marshal(message)
 	if err != nil {
 		return err
 	}
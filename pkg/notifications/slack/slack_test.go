@@ -144,7 +144,8 @@ func TestGenerateMessage(t *testing.T) {
 			Reason: res,
 		}
 
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		var messageStringBuilder strings.Builder
 		for _, msg := range e.Reason.Verbose() {
@@ -190,7 +191,8 @@ func TestGenerateMessage(t *testing.T) {
 			Reason: res,
 		}
 
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		var messageStringBuilder strings.Builder
 		for _, msg := range e.Reason.Verbose() {
@@ -236,7 +238,8 @@ func TestGenerateMessage(t *testing.T) {
 			Reason: res,
 		}
 
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		var messageStringBuilder strings.Builder
 		for _, msg := range e.Reason.Verbose() {
@@ -282,7 +285,8 @@ func TestGenerateMessage(t *testing.T) {
 			Reason: res,
 		}
 
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 
 		var messageStringBuilder strings.Builder
 		for _, msg := range e.Reason.Verbose() {
@@ -301,3 +305,31 @@ func TestGenerateMessage(t *testing.T) {
 		assert.Equal(t, event.Phase(phaseField.Value), e.Phase)
 	})
 }
+
+func TestGenerateMessageWithTemplate(t *testing.T) {
+	s := Slack{
+		config: v1alpha2.Notification{
+			Template: &v1alpha2.NotificationTemplate{
+				Title: "{{ .Jenkins.Namespace }}/{{ .Jenkins.Name }}",
+				Body:  "{{ .Reason }}: {{ join .Messages \", \" }}",
+			},
+		},
+	}
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+
+	message, err := s.generateMessage(e)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "default/test-cr", message.Attachments[0].Title)
+	assert.Equal(t, "PodRestart: Jenkins master pod restarted by kubernetes: test-reason-1", message.Attachments[0].Fields[0].Value)
+}
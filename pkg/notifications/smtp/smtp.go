@@ -3,8 +3,13 @@ package smtp
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net"
+	netsmtp "net/smtp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
@@ -20,6 +25,12 @@ import (
 const (
 	mailSubject = "Jenkins Operator Notification"
 
+	// timeout is the maximum time of the whole SMTP session
+	timeout = 30 * time.Second
+
+	// implicitTLSPort is the submission port with the connection encrypted from the start
+	implicitTLSPort = 465
+
 	infoColor    = "blue"
 	warningColor = "red"
 	defaultColor = "gray"
@@ -45,6 +56,14 @@ const (
 		<h6 style="font-size: 11px; color: grey; margin-top: 15px;">Powered by Jenkins Operator <3</h6>
 </body>
 </html>`
+
+	plainContent = `%s
+
+%s
+
+CR name: %s
+Phase: %s
+`
 )
 
 // SMTP is Simple Mail Transport Protocol used for sending emails.
@@ -58,68 +77,210 @@ func New(k8sClient k8sclient.Client, config v1alpha2.Notification) *SMTP {
 	return &SMTP{k8sClient: k8sClient, config: config}
 }
 
-func (s SMTP) generateMessage(e event.Event) *gomail.Message {
+func (s SMTP) to() []string {
+	var to []string
+	if len(s.config.SMTP.To) > 0 {
+		to = append(to, s.config.SMTP.To)
+	}
+	return append(to, s.config.SMTP.Recipients...)
+}
+
+func (s SMTP) generateMessage(e event.Event) (*gomail.Message, error) {
+	messages := provider.Messages(s.config, e)
+
 	var statusMessage strings.Builder
-	var reasons string
+	statusMessage.WriteString("<ul><li>")
+	statusMessage.WriteString(strings.Join(messages, "</li><li>"))
+	statusMessage.WriteString("</li></ul>")
+	plainMessage := " - " + strings.Join(messages, "\n - ")
 
-	if s.config.Verbose {
-		reasons = strings.TrimRight(strings.Join(e.Reason.Verbose(), "</li><li>"), "<li>")
-	} else {
-		reasons = strings.TrimRight(strings.Join(e.Reason.Short(), "</li><li>"), "<li>")
+	title, err := provider.Title(s.config, e)
+	if err != nil {
+		return nil, err
+	}
+	message := statusMessage.String()
+	if body, ok, err := provider.Body(s.config, e); err != nil {
+		return nil, err
+	} else if ok {
+		message = body
+		plainMessage = body
 	}
 
-	statusMessage.WriteString("<ul><li>")
-	statusMessage.WriteString(reasons)
-	statusMessage.WriteString("</ul>")
+	subject := mailSubject
+	if s.config.Template != nil && len(s.config.Template.Title) > 0 {
+		subject = title
+	}
 
-	htmlMessage := fmt.Sprintf(content, s.getStatusColor(e.Level), provider.NotificationTitle(e), statusMessage.String(), e.Jenkins.Name, e.Phase)
-	message := gomail.NewMessage()
+	htmlMessage := fmt.Sprintf(content, s.getStatusColor(e.Level), title, message, e.Jenkins.Name, e.Phase)
+	mail := gomail.NewMessage()
 
-	message.SetHeader("From", s.config.SMTP.From)
-	message.SetHeader("To", s.config.SMTP.To)
-	message.SetHeader("Subject", mailSubject)
-	message.SetBody("text/html", htmlMessage)
+	mail.SetHeader("From", s.config.SMTP.From)
+	mail.SetHeader("To", s.to()...)
+	if len(s.config.SMTP.CC) > 0 {
+		mail.SetHeader("Cc", s.config.SMTP.CC...)
+	}
+	mail.SetHeader("Subject", subject)
+	mail.SetBody("text/plain", fmt.Sprintf(plainContent, title, plainMessage, e.Jenkins.Name, e.Phase))
+	mail.AddAlternative("text/html", htmlMessage)
 
-	return message
+	return mail, nil
 }
 
-// Send is function for sending notification by SMTP server.
-func (s SMTP) Send(e event.Event) error {
-	usernameSecret := &corev1.Secret{}
-	passwordSecret := &corev1.Secret{}
+func (s SMTP) getSecretValue(namespace string, selector v1alpha2.SecretKeySelector, name string) (string, error) {
+	secret := &corev1.Secret{}
+	err := s.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: namespace}, secret)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
 
+	value := string(secret.Data[selector.Key])
+	if value == "" {
+		return "", errors.Errorf("SMTP %s is empty in secret '%s/%s[%s]", name, namespace, selector.Name, selector.Key)
+	}
+
+	return value, nil
+}
+
+func (s SMTP) getAuth(namespace string) (netsmtp.Auth, error) {
 	usernameSelector := s.config.SMTP.UsernameSecretKeySelector
 	passwordSelector := s.config.SMTP.PasswordSecretKeySelector
+	if usernameSelector.Name == "" && passwordSelector.Name == "" {
+		return nil, nil // relay without authentication
+	}
 
-	err := s.k8sClient.Get(context.TODO(), types.NamespacedName{Name: usernameSelector.Name, Namespace: e.Jenkins.Namespace}, usernameSecret)
+	username, err := s.getSecretValue(namespace, usernameSelector, "username")
 	if err != nil {
-		return err
+		return nil, err
+	}
+	password, err := s.getSecretValue(namespace, passwordSelector, "password")
+	if err != nil {
+		return nil, err
+	}
+
+	return netsmtp.PlainAuth("", username, password, s.config.SMTP.Server), nil
+}
+
+func (s SMTP) getTLSConfig(namespace string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         s.config.SMTP.Server,
+		InsecureSkipVerify: s.config.SMTP.TLSInsecureSkipVerify,
 	}
 
-	err = s.k8sClient.Get(context.TODO(), types.NamespacedName{Name: passwordSelector.Name, Namespace: e.Jenkins.Namespace}, passwordSecret)
+	if s.config.SMTP.CABundleSecretKeySelector != nil {
+		caBundle, err := s.getSecretValue(namespace, *s.config.SMTP.CABundleSecretKeySelector, "CA bundle")
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(caBundle)) {
+			return nil, errors.New("SMTP CA bundle doesn't contain any PEM encoded certificate")
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// tlsMode returns the configured TLS mode, implicit TLS is used by default on port 465 like the gomail dialer did
+func (s SMTP) tlsMode() v1alpha2.SMTPTLSMode {
+	if len(s.config.SMTP.TLSMode) == 0 && s.config.SMTP.Port == implicitTLSPort {
+		return v1alpha2.SMTPTLSModeTLS
+	}
+	return s.config.SMTP.TLSMode
+}
+
+func (s SMTP) dial(tlsConfig *tls.Config) (*netsmtp.Client, error) {
+	tlsMode := s.tlsMode()
+	address := net.JoinHostPort(s.config.SMTP.Server, strconv.Itoa(s.config.SMTP.Port))
+	dialer := &net.Dialer{Timeout: timeout}
+
+	var conn net.Conn
+	var err error
+	if tlsMode == v1alpha2.SMTPTLSModeTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
 	if err != nil {
-		return err
+		return nil, errors.WithStack(err)
+	}
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		_ = conn.Close()
+		return nil, errors.WithStack(err)
+	}
+
+	client, err := netsmtp.NewClient(conn, s.config.SMTP.Server)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.WithStack(err)
 	}
 
-	usernameSecretValue := string(usernameSecret.Data[usernameSelector.Key])
-	if usernameSecretValue == "" {
-		return errors.Errorf("SMTP username is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, usernameSelector.Name, usernameSelector.Key)
+	switch tlsMode {
+	case v1alpha2.SMTPTLSModeTLS, v1alpha2.SMTPTLSModeNone:
+		return client, nil
 	}
 
-	passwordSecretValue := string(passwordSecret.Data[passwordSelector.Key])
-	if passwordSecretValue == "" {
-		return errors.Errorf("SMTP password is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, passwordSelector.Name, passwordSelector.Key)
+	if ok, _ := client.Extension("STARTTLS"); !ok {
+		if tlsMode == v1alpha2.SMTPTLSModeStartTLS {
+			_ = client.Close()
+			return nil, errors.Errorf("SMTP server '%s' doesn't support STARTTLS", address)
+		}
+		return client, nil
+	}
+	if err = client.StartTLS(tlsConfig); err != nil {
+		_ = client.Close()
+		return nil, errors.WithStack(err)
 	}
 
-	mailer := gomail.NewDialer(s.config.SMTP.Server, s.config.SMTP.Port, usernameSecretValue, passwordSecretValue)
-	mailer.TLSConfig = &tls.Config{InsecureSkipVerify: s.config.SMTP.TLSInsecureSkipVerify}
+	return client, nil
+}
 
-	message := s.generateMessage(e)
-	if err := mailer.DialAndSend(message); err != nil {
+// Send is function for sending notification by SMTP server.
+func (s SMTP) Send(e event.Event) error {
+	auth, err := s.getAuth(e.Jenkins.Namespace)
+	if err != nil {
 		return err
 	}
+	tlsConfig, err := s.getTLSConfig(e.Jenkins.Namespace)
+	if err != nil {
+		return err
+	}
+	message, err := s.generateMessage(e)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.dial(tlsConfig)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if auth != nil {
+		if err = client.Auth(auth); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err = client.Mail(s.config.SMTP.From); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, recipient := range append(s.to(), s.config.SMTP.CC...) {
+		if err = client.Rcpt(recipient); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err = message.WriteTo(writer); err != nil {
+		return errors.WithStack(err)
+	}
+	if err = writer.Close(); err != nil {
+		return errors.WithStack(err)
+	}
 
-	return nil
+	return errors.WithStack(client.Quit())
 }
 
 func (s SMTP) getStatusColor(logLevel v1alpha2.NotificationLevel) event.StatusColor {
@@ -1,14 +1,24 @@
 package smtp
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"mime/quotedprintable"
+	"math/big"
+	"mime"
+	"mime/multipart"
 	"net"
-	"regexp"
+	"net/mail"
 	"testing"
 	"time"
 
@@ -18,6 +28,7 @@ import (
 
 	"github.com/emersion/go-smtp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -26,17 +37,16 @@ import (
 const (
 	testSMTPUsername = "username"
 	testSMTPPassword = "password"
-
-	testSMTPPort = 1025
+	testSMTPServer   = "127.0.0.1"
 
 	testFrom    = "test@localhost"
 	testTo      = "test.to@localhost"
 	testSubject = "Jenkins Operator Notification"
 
-	// Headers titles
-	fromHeader    = "From"
-	toHeader      = "To"
-	subjectHeader = "Subject"
+	testSecretName              = "test-secret"
+	testUsernameSelectorKeyName = "test-username-selector"
+	testPasswordSelectorKeyName = "test-password-selector"
+	testCABundleSelectorKeyName = "test-ca-bundle-selector"
 
 	nilConst = "nil"
 )
@@ -53,78 +63,140 @@ var (
 	testLevel = v1alpha2.NotificationLevelWarning
 )
 
+// testMessage is the email received by the test server.
+type testMessage struct {
+	tls        bool
+	from       string
+	recipients []string
+	data       []byte
+}
+
 type testServer struct {
-	event event.Event
+	anonymous bool
+	messages  chan testMessage
 }
 
 // Login handles a login command with username and password.
-func (bkd *testServer) Login(_ *smtp.ConnectionState, username, password string) (smtp.Session, error) {
+func (bkd *testServer) Login(state *smtp.ConnectionState, username, password string) (smtp.Session, error) {
 	if username != testSMTPUsername || password != testSMTPPassword {
 		return nil, errors.New("invalid username or password")
 	}
-	return &testSession{event: bkd.event}, nil
+	return &testSession{server: bkd, message: testMessage{tls: state.TLS.HandshakeComplete}}, nil
 }
 
-// AnonymousLogin requires clients to authenticate using SMTP AUTH before sending emails
-func (bkd *testServer) AnonymousLogin(_ *smtp.ConnectionState) (smtp.Session, error) {
-	return nil, smtp.ErrAuthRequired
+// AnonymousLogin allows sending emails without authentication only if the server is a relay.
+func (bkd *testServer) AnonymousLogin(state *smtp.ConnectionState) (smtp.Session, error) {
+	if !bkd.anonymous {
+		return nil, smtp.ErrAuthRequired
+	}
+	return &testSession{server: bkd, message: testMessage{tls: state.TLS.HandshakeComplete}}, nil
 }
 
 // A Session is returned after successful login.
 type testSession struct {
-	event event.Event
+	server  *testServer
+	message testMessage
 }
 
 func (s *testSession) Mail(from string) error {
-	if from != testFrom {
-		return fmt.Errorf("`From` header is not equal: '%s', expected '%s'", from, testFrom)
-	}
+	s.message.from = from
 	return nil
 }
 
 func (s *testSession) Rcpt(to string) error {
-	if to != testTo {
-		return fmt.Errorf("`To` header is not equal: '%s', expected '%s'", to, testTo)
-	}
+	s.message.recipients = append(s.message.recipients, to)
 	return nil
 }
 
 func (s *testSession) Data(r io.Reader) error {
-	contentRegex := regexp.MustCompile(`\t+<tr>\n\t+<td><b>(.*):</b></td>\n\t+<td>(.*)</td>\n\t+</tr>`)
-	headersRegex := regexp.MustCompile(`(.*):\s(.*)`)
-
-	b, err := ioutil.ReadAll(quotedprintable.NewReader(r))
+	data, err := ioutil.ReadAll(r)
 	if err != nil {
 		return err
 	}
+	s.message.data = data
+	s.server.messages <- s.message
+	return nil
+}
 
-	content := contentRegex.FindAllStringSubmatch(string(b), -1)
-	headers := headersRegex.FindAllStringSubmatch(string(b), -1)
+func (s *testSession) Reset() {}
 
-	if s.event.Jenkins.Name == content[0][1] {
-		return fmt.Errorf("jenkins CR not identical: %s, expected: %s", content[0][1], s.event.Jenkins.Name)
-	} else if string(s.event.Phase) == content[1][1] {
-		return fmt.Errorf("phase not identical: %s, expected: %s", content[1][1], s.event.Phase)
+func (s *testSession) Logout() error {
+	return nil
+}
+
+// generateCertificate returns self-signed certificate for testSMTPServer and its PEM encoding.
+func generateCertificate(t *testing.T) (tls.Certificate, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"Jenkins Operator"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP(testSMTPServer)},
 	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
 
-	for i := range headers {
-		switch {
-		case headers[i][1] == fromHeader && headers[i][2] != testFrom:
-			return fmt.Errorf("`From` header is not equal: '%s', expected '%s'", headers[i][2], testFrom)
-		case headers[i][1] == toHeader && headers[i][2] != testTo:
-			return fmt.Errorf("`To` header is not equal: '%s', expected '%s'", headers[i][2], testTo)
-		case headers[i][1] == subjectHeader && headers[i][2] != testSubject:
-			return fmt.Errorf("`Subject` header is not equal: '%s', expected '%s'", headers[i][2], testSubject)
-		}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// startServer starts the SMTP server and returns its port, the server supports STARTTLS if tlsConfig is set.
+func startServer(t *testing.T, backend *testServer, tlsConfig *tls.Config, implicitTLS bool) int {
+	s := smtp.NewServer(backend)
+	s.Domain = "localhost"
+	s.ReadTimeout = 10 * time.Second
+	s.WriteTimeout = 10 * time.Second
+	s.MaxMessageBytes = 1024 * 1024
+	s.MaxRecipients = 50
+	s.AllowInsecureAuth = true
+
+	l, err := net.Listen("tcp", fmt.Sprintf("%s:0", testSMTPServer))
+	require.NoError(t, err)
+	if implicitTLS {
+		l = tls.NewListener(l, tlsConfig)
+	} else {
+		s.TLSConfig = tlsConfig
 	}
 
-	return nil
+	go func() {
+		_ = s.Serve(l)
+	}()
+	t.Cleanup(s.Close)
+
+	return l.Addr().(*net.TCPAddr).Port
 }
 
-func (s *testSession) Reset() {}
+// parseMessage returns headers and text/plain and text/html parts of the email.
+func parseMessage(t *testing.T, data []byte) (mail.Header, map[string]string) {
+	message, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(part)
+		require.NoError(t, err)
+		contentType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		parts[contentType] = string(body)
+	}
 
-func (s *testSession) Logout() error {
-	return nil
+	return message.Header, parts
 }
 
 func TestSMTP_Send(t *testing.T) {
@@ -139,49 +211,11 @@ func TestSMTP_Send(t *testing.T) {
 		Level:  testLevel,
 		Reason: testReason,
 	}
-
-	fakeClient := fake.NewClientBuilder().Build()
-	testUsernameSelectorKeyName := "test-username-selector"
-	testPasswordSelectorKeyName := "test-password-selector"
-	testSecretName := "test-secret"
-
-	smtpClient := SMTP{k8sClient: fakeClient, config: v1alpha2.Notification{
-		SMTP: &v1alpha2.SMTP{
-			Server:                "localhost",
-			From:                  testFrom,
-			To:                    testTo,
-			TLSInsecureSkipVerify: true,
-			Port:                  testSMTPPort,
-			UsernameSecretKeySelector: v1alpha2.SecretKeySelector{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: testSecretName,
-				},
-				Key: testUsernameSelectorKeyName,
-			},
-			PasswordSecretKeySelector: v1alpha2.SecretKeySelector{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: testSecretName,
-				},
-				Key: testPasswordSelectorKeyName,
-			},
-		},
-	}}
-
-	ts := &testServer{event: e}
-
-	// Create fake SMTP server
-
-	s := smtp.NewServer(ts)
-
-	s.Addr = fmt.Sprintf(":%d", testSMTPPort)
-	s.Domain = "localhost"
-	s.ReadTimeout = 10 * time.Second
-	s.WriteTimeout = 10 * time.Second
-	s.MaxMessageBytes = 1024 * 1024
-	s.MaxRecipients = 50
-	s.AllowInsecureAuth = true
+	certificate, caBundle := generateCertificate(t)
+	serverTLSConfig := &tls.Config{Certificates: []tls.Certificate{certificate}}
 
 	// Create secrets
+	fakeClient := fake.NewClientBuilder().Build()
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      testSecretName,
@@ -191,23 +225,143 @@ func TestSMTP_Send(t *testing.T) {
 		Data: map[string][]byte{
 			testUsernameSelectorKeyName: []byte(testSMTPUsername),
 			testPasswordSelectorKeyName: []byte(testSMTPPassword),
+			testCABundleSelectorKeyName: caBundle,
 		},
 	}
-
 	err := fakeClient.Create(context.TODO(), secret)
-	assert.NoError(t, err)
+	require.NoError(t, err)
 
-	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", testSMTPPort))
-	assert.NoError(t, err)
+	credentials := func(config *v1alpha2.SMTP) *v1alpha2.SMTP {
+		config.UsernameSecretKeySelector = v1alpha2.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName},
+			Key:                  testUsernameSelectorKeyName,
+		}
+		config.PasswordSecretKeySelector = v1alpha2.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName},
+			Key:                  testPasswordSelectorKeyName,
+		}
+		return config
+	}
+	caBundleSelector := &v1alpha2.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName},
+		Key:                  testCABundleSelectorKeyName,
+	}
 
-	go func() {
-		err := s.Serve(l)
-		assert.NoError(t, err)
-	}()
+	t.Run("authentication without TLS", func(t *testing.T) {
+		backend := &testServer{messages: make(chan testMessage, 1)}
+		port := startServer(t, backend, nil, false)
+		smtpClient := SMTP{k8sClient: fakeClient, config: v1alpha2.Notification{
+			SMTP: credentials(&v1alpha2.SMTP{
+				Server: testSMTPServer,
+				Port:   port,
+				From:   testFrom,
+				To:     testTo,
+			}),
+		}}
+
+		err := smtpClient.Send(e)
+
+		require.NoError(t, err)
+		message := <-backend.messages
+		assert.False(t, message.tls)
+		assert.Equal(t, testFrom, message.from)
+		assert.Equal(t, []string{testTo}, message.recipients)
+
+		headers, parts := parseMessage(t, message.data)
+		assert.Equal(t, testFrom, headers.Get("From"))
+		assert.Equal(t, testTo, headers.Get("To"))
+		assert.Equal(t, testSubject, headers.Get("Subject"))
+		assert.Contains(t, parts["text/plain"], " - "+testReason.Short()[0]+"\n")
+		assert.Contains(t, parts["text/plain"], "CR name: "+testCrName+"\n")
+		assert.Contains(t, parts["text/plain"], "Phase: "+string(testPhase)+"\n")
+		assert.Contains(t, parts["text/html"], "<ul><li>"+testReason.Short()[0]+"</li></ul>")
+		assert.Contains(t, parts["text/html"], "<td>"+testCrName+"</td>")
+	})
+
+	t.Run("STARTTLS relay without authentication", func(t *testing.T) {
+		backend := &testServer{anonymous: true, messages: make(chan testMessage, 1)}
+		port := startServer(t, backend, serverTLSConfig, false)
+		smtpClient := SMTP{k8sClient: fakeClient, config: v1alpha2.Notification{
+			SMTP: &v1alpha2.SMTP{
+				Server:                    testSMTPServer,
+				Port:                      port,
+				TLSMode:                   v1alpha2.SMTPTLSModeStartTLS,
+				CABundleSecretKeySelector: caBundleSelector,
+				From:                      testFrom,
+				To:                        testTo,
+				Recipients:                []string{"second.to@localhost"},
+				CC:                        []string{"first.cc@localhost", "second.cc@localhost"},
+			},
+		}}
+
+		err := smtpClient.Send(e)
 
-	err = smtpClient.Send(e)
+		require.NoError(t, err)
+		message := <-backend.messages
+		assert.True(t, message.tls)
+		assert.Equal(t, []string{testTo, "second.to@localhost", "first.cc@localhost", "second.cc@localhost"}, message.recipients)
 
-	assert.NoError(t, err)
+		headers, _ := parseMessage(t, message.data)
+		assert.Equal(t, testTo+", second.to@localhost", headers.Get("To"))
+		assert.Equal(t, "first.cc@localhost, second.cc@localhost", headers.Get("Cc"))
+	})
+
+	t.Run("implicit TLS with authentication", func(t *testing.T) {
+		backend := &testServer{messages: make(chan testMessage, 1)}
+		port := startServer(t, backend, serverTLSConfig, true)
+		smtpClient := SMTP{k8sClient: fakeClient, config: v1alpha2.Notification{
+			SMTP: credentials(&v1alpha2.SMTP{
+				Server:                    testSMTPServer,
+				Port:                      port,
+				TLSMode:                   v1alpha2.SMTPTLSModeTLS,
+				CABundleSecretKeySelector: caBundleSelector,
+				From:                      testFrom,
+				To:                        testTo,
+			}),
+		}}
+
+		err := smtpClient.Send(e)
+
+		require.NoError(t, err)
+		message := <-backend.messages
+		assert.True(t, message.tls)
+		assert.Equal(t, []string{testTo}, message.recipients)
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		backend := &testServer{anonymous: true, messages: make(chan testMessage, 1)}
+		port := startServer(t, backend, serverTLSConfig, false)
+		smtpClient := SMTP{k8sClient: fakeClient, config: v1alpha2.Notification{
+			SMTP: &v1alpha2.SMTP{
+				Server: testSMTPServer,
+				Port:   port,
+				From:   testFrom,
+				To:     testTo,
+			},
+		}}
+
+		err := smtpClient.Send(e)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("STARTTLS not supported by server", func(t *testing.T) {
+		backend := &testServer{anonymous: true, messages: make(chan testMessage, 1)}
+		port := startServer(t, backend, nil, false)
+		smtpClient := SMTP{k8sClient: fakeClient, config: v1alpha2.Notification{
+			SMTP: &v1alpha2.SMTP{
+				Server:  testSMTPServer,
+				Port:    port,
+				TLSMode: v1alpha2.SMTPTLSModeStartTLS,
+				From:    testFrom,
+				To:      testTo,
+			},
+		}}
+
+		err := smtpClient.Send(e)
+
+		assert.EqualError(t, err, fmt.Sprintf("SMTP server '%s:%d' doesn't support STARTTLS", testSMTPServer, port))
+	})
 }
 
 func TestGenerateMessage(t *testing.T) {
@@ -240,7 +394,8 @@ func TestGenerateMessage(t *testing.T) {
 				},
 			},
 		}
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 		assert.NotNil(t, message)
 	})
 
@@ -273,7 +428,8 @@ func TestGenerateMessage(t *testing.T) {
 				},
 			},
 		}
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 		assert.NotNil(t, message)
 	})
 
@@ -306,7 +462,29 @@ func TestGenerateMessage(t *testing.T) {
 				},
 			},
 		}
-		message := s.generateMessage(e)
+		message, err := s.generateMessage(e)
+		assert.NoError(t, err)
 		assert.NotNil(t, message)
 	})
 }
+
+func TestSMTP_tlsMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		port    int
+		tlsMode v1alpha2.SMTPTLSMode
+		want    v1alpha2.SMTPTLSMode
+	}{
+		{name: "implicit TLS port", port: 465, want: v1alpha2.SMTPTLSModeTLS},
+		{name: "implicit TLS port with configured mode", port: 465, tlsMode: v1alpha2.SMTPTLSModeNone, want: v1alpha2.SMTPTLSModeNone},
+		{name: "submission port", port: 587, want: ""},
+		{name: "configured mode", port: 587, tlsMode: v1alpha2.SMTPTLSModeStartTLS, want: v1alpha2.SMTPTLSModeStartTLS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			smtpClient := SMTP{config: v1alpha2.Notification{SMTP: &v1alpha2.SMTP{Port: tt.port, TLSMode: tt.tlsMode}}}
+
+			assert.Equal(t, tt.want, smtpClient.tlsMode())
+		})
+	}
+}
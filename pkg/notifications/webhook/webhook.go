@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SignatureHeader is the HTTP header which contains HMAC-SHA256 signature of the request body
+	SignatureHeader = "X-Jenkins-Operator-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Webhook is a generic HTTP webhook notification service.
+type Webhook struct {
+	httpClient http.Client
+	k8sClient  k8sclient.Client
+	config     v1alpha2.Notification
+}
+
+// New returns instance of Webhook.
+func New(k8sClient k8sclient.Client, config v1alpha2.Notification, httpClient http.Client) *Webhook {
+	return &Webhook{k8sClient: k8sClient, config: config, httpClient: httpClient}
+}
+
+// Message is representation of json message.
+type Message struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Phase     string    `json:"phase"`
+	Level     string    `json:"level"`
+	Reason    string    `json:"reason"`
+	Short     []string  `json:"short"`
+	Verbose   []string  `json:"verbose"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (w Webhook) generateMessage(e event.Event) Message {
+	return Message{
+		Name:      e.Jenkins.Name,
+		Namespace: e.Jenkins.Namespace,
+		Phase:     string(e.Phase),
+		Level:     string(e.Level),
+		Reason:    reason.TypeName(e.Reason),
+		Short:     e.Reason.Short(),
+		Verbose:   e.Reason.Verbose(),
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+func (w Webhook) getSecretValue(namespace string, selector v1alpha2.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	err := w.k8sClient.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: namespace}, secret)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return string(secret.Data[selector.Key]), nil
+}
+
+func (w Webhook) getHeaders(namespace string) (map[string]string, error) {
+	headers := map[string]string{}
+	if w.config.Webhook.HeadersSecretRef == nil {
+		return headers, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := w.k8sClient.Get(context.TODO(), types.NamespacedName{Name: w.config.Webhook.HeadersSecretRef.Name, Namespace: namespace}, secret)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for name, value := range secret.Data {
+		headers[name] = string(value)
+	}
+
+	return headers, nil
+}
+
+func sign(key string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send is function for sending directly to API.
+func (w Webhook) Send(e event.Event) error {
+	selector := w.config.Webhook.URLSecretKeySelector
+	url, err := w.getSecretValue(e.Jenkins.Namespace, selector)
+	if err != nil {
+		return err
+	}
+	if url == "" {
+		return errors.Errorf("Webhook URL is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	headers, err := w.getHeaders(e.Jenkins.Namespace)
+	if err != nil {
+		return err
+	}
+
+	msg, err := json.Marshal(w.generateMessage(e))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	request, err := http.NewRequest("POST", url, bytes.NewBuffer(msg))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	if hmacSelector := w.config.Webhook.HMACSecretKeySelector; hmacSelector != nil {
+		key, err := w.getSecretValue(e.Jenkins.Namespace, *hmacSelector)
+		if err != nil {
+			return err
+		}
+		if key == "" {
+			return errors.Errorf("Webhook HMAC key is empty in secret '%s/%s[%s]", e.Jenkins.Namespace, hmacSelector.Name, hmacSelector.Key)
+		}
+		request.Header.Set(SignatureHeader, sign(key, msg))
+	}
+
+	resp, err := w.httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.New(fmt.Sprintf("Invalid response from server: %s", resp.Status))
+	}
+
+	return nil
+}
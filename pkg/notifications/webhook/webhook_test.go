@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	testPhase     = event.PhaseUser
+	testCrName    = "test-cr"
+	testNamespace = "default"
+	testReason    = reason.NewPodRestart(
+		reason.KubernetesSource,
+		[]string{"test-reason-1"},
+		[]string{"test-verbose-1"}...,
+	)
+	testLevel = v1alpha2.NotificationLevelWarning
+)
+
+func TestWebhook_Send(t *testing.T) {
+	testURLSelectorKeyName := "test-url-selector"
+	testHMACSelectorKeyName := "test-hmac-selector"
+	testSecretName := "test-secret"
+	testHeadersSecretName := "test-headers-secret"
+	testHMACKey := "test-hmac-key"
+
+	e := event.Event{
+		Jenkins: v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCrName,
+				Namespace: testNamespace,
+			},
+		},
+		Phase:  testPhase,
+		Level:  testLevel,
+		Reason: testReason,
+	}
+
+	t.Run("happy", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().Build()
+		webhook := Webhook{k8sClient: fakeClient, config: v1alpha2.Notification{
+			Webhook: &v1alpha2.Webhook{
+				URLSecretKeySelector: v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName},
+					Key:                  testURLSelectorKeyName,
+				},
+				HMACSecretKeySelector: &v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName},
+					Key:                  testHMACSelectorKeyName,
+				},
+				HeadersSecretRef: &v1alpha2.SecretRef{Name: testHeadersSecretName},
+			},
+		}}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var message Message
+			if err := json.Unmarshal(body, &message); err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, testCrName, message.Name)
+			assert.Equal(t, testNamespace, message.Namespace)
+			assert.Equal(t, string(testPhase), message.Phase)
+			assert.Equal(t, string(testLevel), message.Level)
+			assert.Equal(t, "PodRestart", message.Reason)
+			assert.Equal(t, testReason.Short(), message.Short)
+			assert.Equal(t, testReason.Verbose(), message.Verbose)
+			assert.False(t, message.Timestamp.IsZero())
+			assert.Equal(t, sign(testHMACKey, body), r.Header.Get(SignatureHeader))
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		}))
+		defer server.Close()
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testSecretName,
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				testURLSelectorKeyName:  []byte(server.URL),
+				testHMACSelectorKeyName: []byte(testHMACKey),
+			},
+		}
+		headersSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testHeadersSecretName,
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				"Authorization": []byte("Bearer token"),
+			},
+		}
+		require.NoError(t, fakeClient.Create(context.TODO(), secret))
+		require.NoError(t, fakeClient.Create(context.TODO(), headersSecret))
+
+		err := webhook.Send(e)
+		assert.NoError(t, err)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().Build()
+		webhook := Webhook{k8sClient: fakeClient, config: v1alpha2.Notification{
+			Webhook: &v1alpha2.Webhook{
+				URLSecretKeySelector: v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName},
+					Key:                  testURLSelectorKeyName,
+				},
+			},
+		}}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get(SignatureHeader))
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testSecretName,
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				testURLSelectorKeyName: []byte(server.URL),
+			},
+		}
+		require.NoError(t, fakeClient.Create(context.TODO(), secret))
+
+		err := webhook.Send(e)
+		assert.Error(t, err)
+	})
+
+	t.Run("empty URL", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().Build()
+		webhook := Webhook{k8sClient: fakeClient, config: v1alpha2.Notification{
+			Webhook: &v1alpha2.Webhook{
+				URLSecretKeySelector: v1alpha2.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: testSecretName},
+					Key:                  testURLSelectorKeyName,
+				},
+			},
+		}}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testSecretName,
+				Namespace: testNamespace,
+			},
+		}
+		require.NoError(t, fakeClient.Create(context.TODO(), secret))
+
+		err := webhook.Send(e)
+		assert.Error(t, err)
+	})
+}